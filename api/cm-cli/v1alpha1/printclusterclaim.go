@@ -23,6 +23,15 @@ type PrintClusterClaimSpec struct {
 	Lifetime            string               `json:"lifetime"`
 	Age                 string               `json:"age"`
 	ErrorMessage        string               `json:"error"`
+	FailureCategory     string               `json:"failureCategory"`
+	FailureHint         string               `json:"failureHint"`
+	Subjects            string               `json:"subjects"`
+	LeaseHolder         string               `json:"leaseHolder"`
+	LeaseExpiry         string               `json:"leaseExpiry"`
+	LeaseNote           string               `json:"leaseNote"`
+	Creator             string               `json:"creator"`
+	Description         string               `json:"description"`
+	Ticket              string               `json:"ticket"`
 }
 
 // PrintClusterClaim is the Schema for the authrealms API
@@ -39,6 +48,15 @@ type PrintClusterClaimSpec struct {
 // +kubebuilder:printcolumn:name="Id",type="string",JSONPath=".spec.id"
 // +kubebuilder:printcolumn:name="Age",type="string",JSONPath=".spec.age"
 // +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".spec.Error"
+// +kubebuilder:printcolumn:name="Failure",type="string",JSONPath=".spec.failureCategory"
+// +kubebuilder:printcolumn:name="Failure_Hint",type="string",JSONPath=".spec.failureHint",priority=1
+// +kubebuilder:printcolumn:name="Subjects",type="string",JSONPath=".spec.subjects",priority=1
+// +kubebuilder:printcolumn:name="Holder",type="string",JSONPath=".spec.leaseHolder"
+// +kubebuilder:printcolumn:name="Lease_Expiry",type="string",JSONPath=".spec.leaseExpiry",priority=1
+// +kubebuilder:printcolumn:name="Lease_Note",type="string",JSONPath=".spec.leaseNote",priority=1
+// +kubebuilder:printcolumn:name="Creator",type="string",JSONPath=".spec.creator",priority=1
+// +kubebuilder:printcolumn:name="Description",type="string",JSONPath=".spec.description",priority=1
+// +kubebuilder:printcolumn:name="Ticket",type="string",JSONPath=".spec.ticket",priority=1
 
 type PrintClusterClaim struct {
 	metav1.TypeMeta   `json:",inline"`
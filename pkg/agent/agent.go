@@ -0,0 +1,195 @@
+// Copyright Contributors to the Open Cluster Management project
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	RoleMaster     = "master"
+	RoleWorker     = "worker"
+	RoleAutoAssign = "auto-assign"
+)
+
+// Agent is a host discovered by the assisted installer, booted on the discovery ISO of an InfraEnv
+type Agent struct {
+	Name      string
+	Namespace string
+	// Hostname is the requested hostname or the one reported by the host
+	Hostname string
+	// Cluster is the name of the clusterdeployment the agent is bound to, empty if unbound
+	Cluster string
+	// Role is the requested role or the one assigned by the installer
+	Role     string
+	Approved bool
+	// State is the installation state of the host (ie: known, installing, installed)
+	State string
+}
+
+// ListOptions filters the agents
+type ListOptions struct {
+	// Namespace of the agents, all namespaces if empty
+	Namespace string
+	// Cluster returns only the agents bound to that cluster
+	Cluster string
+	// Unbound returns only the agents not bound to a cluster
+	Unbound bool
+}
+
+// ApproveOptions defines which agents to approve and how to bind them
+type ApproveOptions struct {
+	Namespace string
+	// Names of the agents to approve
+	Names []string
+	// All approves all the agents not yet approved, if Cluster is set only the unbound ones and the ones bound to it
+	All bool
+	// Cluster binds the agents to the clusterdeployment, in the namespace of the same name
+	Cluster string
+	// Role of the agents, master, worker or auto-assign
+	Role string
+	// Hostname of the agent, only if one agent is approved
+	Hostname string
+	// DryRun returns the agents which would be approved without updating them
+	DryRun bool
+}
+
+// List returns the agents sorted by namespace and name
+func List(dynamicClient dynamic.Interface, o ListOptions) ([]Agent, error) {
+	l, err := dynamicClient.Resource(helpers.GvrAgent).Namespace(o.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	agents := make([]Agent, 0, len(l.Items))
+	for i := range l.Items {
+		a := toAgent(&l.Items[i])
+		if (len(o.Cluster) != 0 && a.Cluster != o.Cluster) || (o.Unbound && len(a.Cluster) != 0) {
+			continue
+		}
+		agents = append(agents, a)
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		if agents[i].Namespace != agents[j].Namespace {
+			return agents[i].Namespace < agents[j].Namespace
+		}
+		return agents[i].Name < agents[j].Name
+	})
+	return agents, nil
+}
+
+// Validate checks the role and that the agents to approve are defined
+func (o *ApproveOptions) Validate() error {
+	switch o.Role {
+	case "", RoleMaster, RoleWorker, RoleAutoAssign:
+	default:
+		return fmt.Errorf("role must be %s, %s or %s and got %s", RoleMaster, RoleWorker, RoleAutoAssign, o.Role)
+	}
+	if o.All == (len(o.Names) != 0) {
+		return fmt.Errorf("either agent names or --all must be set")
+	}
+	if len(o.Hostname) != 0 && len(o.Names) != 1 {
+		return fmt.Errorf("hostname can be set only when one agent is approved")
+	}
+	return nil
+}
+
+// Approve approves the agents and binds them to the cluster if set.
+// With Cluster, an agent bound to another cluster is rejected if named and skipped with All.
+func Approve(dynamicClient dynamic.Interface, o ApproveOptions) ([]Agent, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	dr := dynamicClient.Resource(helpers.GvrAgent).Namespace(o.Namespace)
+	selected := make([]*unstructured.Unstructured, 0)
+	if o.All {
+		l, err := dr.List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range l.Items {
+			a := toAgent(&l.Items[i])
+			if a.Approved || (len(o.Cluster) != 0 && len(a.Cluster) != 0 && a.Cluster != o.Cluster) {
+				continue
+			}
+			selected = append(selected, &l.Items[i])
+		}
+	} else {
+		for _, name := range o.Names {
+			u, err := dr.Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			if a := toAgent(u); len(o.Cluster) != 0 && len(a.Cluster) != 0 && a.Cluster != o.Cluster {
+				return nil, fmt.Errorf("agent %s is bound to the cluster %s", name, a.Cluster)
+			}
+			selected = append(selected, u)
+		}
+	}
+	approved := make([]Agent, 0, len(selected))
+	for _, u := range selected {
+		if err := setApproval(u, o); err != nil {
+			return approved, err
+		}
+		if !o.DryRun {
+			updated, err := dr.Update(context.TODO(), u, metav1.UpdateOptions{})
+			if err != nil {
+				return approved, err
+			}
+			u = updated
+		}
+		approved = append(approved, toAgent(u))
+	}
+	return approved, nil
+}
+
+func setApproval(u *unstructured.Unstructured, o ApproveOptions) error {
+	if err := unstructured.SetNestedField(u.Object, true, "spec", "approved"); err != nil {
+		return err
+	}
+	if len(o.Cluster) != 0 {
+		if err := unstructured.SetNestedStringMap(u.Object, map[string]string{
+			"name":      o.Cluster,
+			"namespace": o.Cluster,
+		}, "spec", "clusterDeploymentName"); err != nil {
+			return err
+		}
+	}
+	if len(o.Role) != 0 {
+		if err := unstructured.SetNestedField(u.Object, o.Role, "spec", "role"); err != nil {
+			return err
+		}
+	}
+	if len(o.Hostname) != 0 {
+		if err := unstructured.SetNestedField(u.Object, o.Hostname, "spec", "hostname"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toAgent(u *unstructured.Unstructured) Agent {
+	a := Agent{
+		Name:      u.GetName(),
+		Namespace: u.GetNamespace(),
+	}
+	a.Hostname, _, _ = unstructured.NestedString(u.Object, "spec", "hostname")
+	if len(a.Hostname) == 0 {
+		a.Hostname, _, _ = unstructured.NestedString(u.Object, "status", "inventory", "hostname")
+	}
+	a.Cluster, _, _ = unstructured.NestedString(u.Object, "spec", "clusterDeploymentName", "name")
+	a.Role, _, _ = unstructured.NestedString(u.Object, "spec", "role")
+	if len(a.Role) == 0 || a.Role == RoleAutoAssign {
+		if role, _, _ := unstructured.NestedString(u.Object, "status", "role"); len(role) != 0 {
+			a.Role = role
+		}
+	}
+	a.Approved, _, _ = unstructured.NestedBool(u.Object, "spec", "approved")
+	a.State, _, _ = unstructured.NestedString(u.Object, "status", "debugInfo", "state")
+	return a
+}
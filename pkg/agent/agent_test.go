@@ -0,0 +1,179 @@
+// Copyright Contributors to the Open Cluster Management project
+package agent
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func newAgent(name, namespace, cluster string, approved bool) runtime.Object {
+	spec := map[string]interface{}{
+		"approved": approved,
+	}
+	if len(cluster) != 0 {
+		spec["clusterDeploymentName"] = map[string]interface{}{"name": cluster, "namespace": cluster}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "agent-install.openshift.io/v1beta1",
+		"kind":       "Agent",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec":       spec,
+		"status": map[string]interface{}{
+			"inventory": map[string]interface{}{"hostname": name + ".example.com"},
+			"debugInfo": map[string]interface{}{"state": "known"},
+		},
+	}}
+}
+
+func newDynamicClient() dynamic.Interface {
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helpers.GvrAgent: "AgentList",
+		},
+		newAgent("host-1", "edge", "", false),
+		newAgent("host-2", "edge", "", false),
+		newAgent("host-3", "edge", "other", true),
+		newAgent("host-4", "lab", "", false),
+	)
+}
+
+func agentNames(agents []Agent) []string {
+	names := make([]string, 0, len(agents))
+	for _, a := range agents {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+func TestList(t *testing.T) {
+	dynamicClient := newDynamicClient()
+	tests := []struct {
+		name string
+		o    ListOptions
+		want []string
+	}{
+		{name: "all namespaces", o: ListOptions{}, want: []string{"host-1", "host-2", "host-3", "host-4"}},
+		{name: "namespace", o: ListOptions{Namespace: "edge"}, want: []string{"host-1", "host-2", "host-3"}},
+		{name: "cluster", o: ListOptions{Namespace: "edge", Cluster: "other"}, want: []string{"host-3"}},
+		{name: "unbound", o: ListOptions{Namespace: "edge", Unbound: true}, want: []string{"host-1", "host-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agents, err := List(dynamicClient, tt.o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := agentNames(agents); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	agents, _ := List(dynamicClient, ListOptions{Namespace: "edge", Cluster: "other"})
+	want := Agent{Name: "host-3", Namespace: "edge", Hostname: "host-3.example.com", Cluster: "other", Approved: true, State: "known"}
+	if len(agents) != 1 || agents[0] != want {
+		t.Errorf("got %+v, want %+v", agents, want)
+	}
+}
+
+func TestApprove(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       ApproveOptions
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "named agent bound with role and hostname",
+			o:    ApproveOptions{Namespace: "edge", Names: []string{"host-1"}, Cluster: "mycluster", Role: RoleMaster, Hostname: "master-0"},
+			want: []string{"host-1"},
+		},
+		{
+			name: "all unapproved agents of the namespace",
+			o:    ApproveOptions{Namespace: "edge", All: true, Cluster: "mycluster"},
+			want: []string{"host-1", "host-2"},
+		},
+		{
+			name:    "agent bound to another cluster",
+			o:       ApproveOptions{Namespace: "edge", Names: []string{"host-3"}, Cluster: "mycluster"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown agent",
+			o:       ApproveOptions{Namespace: "edge", Names: []string{"host-9"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid role",
+			o:       ApproveOptions{Namespace: "edge", Names: []string{"host-1"}, Role: "bootstrap"},
+			wantErr: true,
+		},
+		{
+			name:    "hostname with several agents",
+			o:       ApproveOptions{Namespace: "edge", All: true, Hostname: "master-0"},
+			wantErr: true,
+		},
+		{
+			name:    "names and all",
+			o:       ApproveOptions{Namespace: "edge", Names: []string{"host-1"}, All: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dynamicClient := newDynamicClient()
+			agents, err := Approve(dynamicClient, tt.o)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Approve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := agentNames(agents); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			for _, name := range tt.want {
+				u, err := dynamicClient.Resource(helpers.GvrAgent).Namespace("edge").Get(context.TODO(), name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				a := toAgent(u)
+				if !a.Approved || a.Cluster != tt.o.Cluster {
+					t.Errorf("agent %s not approved and bound: %+v", name, a)
+				}
+				if len(tt.o.Role) != 0 && a.Role != tt.o.Role {
+					t.Errorf("expected role %s, got %s", tt.o.Role, a.Role)
+				}
+				if len(tt.o.Hostname) != 0 && a.Hostname != tt.o.Hostname {
+					t.Errorf("expected hostname %s, got %s", tt.o.Hostname, a.Hostname)
+				}
+			}
+		})
+	}
+}
+
+func TestApprove_dryRun(t *testing.T) {
+	dynamicClient := newDynamicClient()
+	agents, err := Approve(dynamicClient, ApproveOptions{Namespace: "edge", All: true, Cluster: "mycluster", DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 2 || !agents[0].Approved {
+		t.Errorf("expected the 2 agents to approve, got %+v", agents)
+	}
+	u, err := dynamicClient.Resource(helpers.GvrAgent).Namespace("edge").Get(context.TODO(), "host-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toAgent(u).Approved {
+		t.Error("the agent must not be updated in dry-run")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// FailureCategory is the category of a provision failure
+type FailureCategory string
+
+const (
+	FailureCategoryQuotaExceeded           FailureCategory = "QuotaExceeded"
+	FailureCategoryDNSBaseDomainMissing    FailureCategory = "DNSBaseDomainMissing"
+	FailureCategoryInvalidCredentials      FailureCategory = "InvalidCredentials"
+	FailureCategoryReleaseImageUnavailable FailureCategory = "ReleaseImageUnavailable"
+	FailureCategoryBootstrapTimeout        FailureCategory = "BootstrapTimeout"
+	FailureCategoryUnknown                 FailureCategory = "Unknown"
+)
+
+// Failure is the result of the classification of a provision failure
+type Failure struct {
+	Category FailureCategory
+	// Message is the condition message or installer log line which matched
+	Message string
+	// Hint is a remediation hint for the category
+	Hint string
+	// Stopped is true if hive gave up provisioning the cluster
+	Stopped bool
+}
+
+func (f *Failure) String() string {
+	return fmt.Sprintf("%s: %s", f.Category, f.Message)
+}
+
+type failureRule struct {
+	category FailureCategory
+	hint     string
+	regexp   *regexp.Regexp
+}
+
+// failureRules are evaluated in order, the first matching rule wins
+var failureRules = []failureRule{
+	{
+		category: FailureCategoryInvalidCredentials,
+		hint:     "check the cloud credentials secret of the clusterdeployment, the keys may be invalid, expired or lack permissions",
+		regexp: regexp.MustCompile(`(?i)(AuthenticationFailure|InvalidClientTokenId|SignatureDoesNotMatch|AuthFailure|` +
+			`UnauthorizedOperation|AccessDenied|invalid[_ ]grant|AADSTS\d+|invalid.*credentials|credentials.*invalid|` +
+			`unable to authenticate)`),
+	},
+	{
+		category: FailureCategoryQuotaExceeded,
+		hint:     "free resources or request a quota increase in the cloud account, or use another region",
+		regexp: regexp.MustCompile(`(?i)(quota|LimitExceeded|limit exceeded|exceeded.*limit|InsufficientInstanceCapacity|` +
+			`insufficient capacity|AddressLimitExceeded|VcpuLimitExceeded|TooManyBuckets)`),
+	},
+	{
+		category: FailureCategoryDNSBaseDomainMissing,
+		hint:     "make sure a public DNS zone for the base domain exists in the cloud account used by the cluster",
+		regexp: regexp.MustCompile(`(?i)(DNSNotReady|NoMatchingDNSZone|no public route ?53 zone|hosted zone.*not found|` +
+			`managed zone.*not found|dns zone.*not found|base ?domain.*(not found|does not exist|missing))`),
+	},
+	{
+		category: FailureCategoryReleaseImageUnavailable,
+		hint:     "check that the release image of the clusterimageset exists and is accessible with the pull secret",
+		regexp: regexp.MustCompile(`(?i)(InstallImagesNotResolved|InstallerImageResolutionFailed|ImagePullBackOff|` +
+			`ErrImagePull|manifest unknown|failed to pull|release image.*(not found|unavailable|could not))`),
+	},
+	{
+		category: FailureCategoryBootstrapTimeout,
+		hint:     "the bootstrap did not complete, check the cloud network configuration and run \"cm logs --errors\" for details",
+		regexp: regexp.MustCompile(`(?i)(bootstrap.*(timeout|timed out|failed to complete)|` +
+			`failed to wait for bootstrapping|waiting for the kubernetes api.*(timeout|timed out|deadline))`),
+	},
+}
+
+// failureConditions are the clusterdeployment conditions reporting a failure when their status is true
+var failureConditions = []hivev1.ClusterDeploymentConditionType{
+	hivev1.ProvisionStoppedCondition,
+	hivev1.ProvisionFailedCondition,
+	hivev1.InstallImagesNotResolvedCondition,
+	hivev1.InstallerImageResolutionFailedCondition,
+	hivev1.DNSNotReadyCondition,
+	hivev1.AuthenticationFailureClusterDeploymentCondition,
+	hivev1.InstallLaunchErrorCondition,
+}
+
+// ClassifyMessages returns the failure for the first message matching a known category
+// or an unknown failure for the first message if none matches.
+func ClassifyMessages(messages ...string) *Failure {
+	first := ""
+	for _, m := range messages {
+		m = strings.TrimSpace(m)
+		if len(m) == 0 {
+			continue
+		}
+		if len(first) == 0 {
+			first = m
+		}
+		for _, r := range failureRules {
+			if r.regexp.MatchString(m) {
+				return &Failure{
+					Category: r.category,
+					Message:  m,
+					Hint:     r.hint,
+				}
+			}
+		}
+	}
+	if len(first) == 0 {
+		return nil
+	}
+	return &Failure{
+		Category: FailureCategoryUnknown,
+		Message:  first,
+		Hint:     "run \"cm logs --errors\" to get the installer errors",
+	}
+}
+
+// ClassifyFailure classifies the failure of a clusterdeployment using its conditions,
+// the failed conditions of the clusterprovision and the installer log.
+// It returns nil if no failure is reported.
+func ClassifyFailure(cd *hivev1.ClusterDeployment, cpr *hivev1.ClusterProvision) *Failure {
+	messages := make([]string, 0)
+	stopped := false
+	if cd != nil {
+		for _, ct := range failureConditions {
+			for _, c := range cd.Status.Conditions {
+				if c.Type == ct && c.Status == corev1.ConditionTrue {
+					messages = append(messages, fmt.Sprintf("%s %s: %s", c.Type, c.Reason, c.Message))
+					if ct == hivev1.ProvisionStoppedCondition {
+						stopped = true
+					}
+				}
+			}
+		}
+		for _, c := range cd.Status.Conditions {
+			if c.Type == hivev1.RequirementsMetCondition && c.Status == corev1.ConditionFalse {
+				messages = append(messages, fmt.Sprintf("%s %s: %s", c.Type, c.Reason, c.Message))
+			}
+		}
+	}
+	if cpr != nil {
+		for _, c := range cpr.Status.Conditions {
+			if c.Type == hivev1.ClusterProvisionFailedCondition && c.Status == corev1.ConditionTrue {
+				messages = append(messages, fmt.Sprintf("%s %s: %s", c.Type, c.Reason, c.Message))
+			}
+		}
+		if cpr.Spec.InstallLog != nil {
+			messages = append(messages, ExtractInstallerErrors(*cpr.Spec.InstallLog)...)
+		}
+	}
+	f := ClassifyMessages(messages...)
+	if f != nil {
+		f.Stopped = stopped
+	}
+	return f
+}
+
+// GetClusterDeploymentFailure classifies the failure of a clusterdeployment,
+// the latest clusterprovision is retrieved if the cluster is not installed.
+func GetClusterDeploymentFailure(dynamicClient dynamic.Interface, cd *hivev1.ClusterDeployment) *Failure {
+	if cd.Spec.Installed {
+		return ClassifyFailure(cd, nil)
+	}
+	cpr, err := GetLatestClusterProvision(dynamicClient, cd.Name)
+	if err != nil {
+		cpr = nil
+	}
+	return ClassifyFailure(cd, cpr)
+}
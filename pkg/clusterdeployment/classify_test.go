@@ -0,0 +1,155 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	installLog := func(s string) *string { return &s }
+	tests := []struct {
+		name         string
+		cd           *hivev1.ClusterDeployment
+		cpr          *hivev1.ClusterProvision
+		wantCategory FailureCategory
+		wantStopped  bool
+		wantNil      bool
+	}{
+		{
+			name: "no failure",
+			cd: &hivev1.ClusterDeployment{
+				Status: hivev1.ClusterDeploymentStatus{
+					Conditions: []hivev1.ClusterDeploymentCondition{
+						{Type: hivev1.ProvisionFailedCondition, Status: corev1.ConditionFalse},
+					},
+				},
+			},
+			wantNil: true,
+		},
+		{
+			name: "quota exceeded from condition",
+			cd: &hivev1.ClusterDeployment{
+				Status: hivev1.ClusterDeploymentStatus{
+					Conditions: []hivev1.ClusterDeploymentCondition{
+						{
+							Type:    hivev1.ProvisionFailedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "AWSVPCLimitExceeded",
+							Message: "AWS VPC limit exceeded",
+						},
+					},
+				},
+			},
+			wantCategory: FailureCategoryQuotaExceeded,
+		},
+		{
+			name: "dns base domain missing",
+			cd: &hivev1.ClusterDeployment{
+				Status: hivev1.ClusterDeploymentStatus{
+					Conditions: []hivev1.ClusterDeploymentCondition{
+						{
+							Type:    hivev1.DNSNotReadyCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "DNSNotReady",
+							Message: "DNS Zone not yet available",
+						},
+					},
+				},
+			},
+			wantCategory: FailureCategoryDNSBaseDomainMissing,
+		},
+		{
+			name: "invalid credentials and stopped",
+			cd: &hivev1.ClusterDeployment{
+				Status: hivev1.ClusterDeploymentStatus{
+					Conditions: []hivev1.ClusterDeploymentCondition{
+						{
+							Type:    hivev1.ProvisionStoppedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "InstallAttemptsLimitReached",
+							Message: "Install attempts limit reached",
+						},
+						{
+							Type:    hivev1.AuthenticationFailureClusterDeploymentCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "AuthenticationFailed",
+							Message: "InvalidClientTokenId: The security token included in the request is invalid",
+						},
+					},
+				},
+			},
+			wantCategory: FailureCategoryInvalidCredentials,
+			wantStopped:  true,
+		},
+		{
+			name: "release image unavailable",
+			cd: &hivev1.ClusterDeployment{
+				Status: hivev1.ClusterDeploymentStatus{
+					Conditions: []hivev1.ClusterDeploymentCondition{
+						{
+							Type:    hivev1.InstallImagesNotResolvedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "JobToResolveImagesFailed",
+							Message: "Job hive/imageset failed",
+						},
+					},
+				},
+			},
+			wantCategory: FailureCategoryReleaseImageUnavailable,
+		},
+		{
+			name: "bootstrap timeout from install log",
+			cd:   &hivev1.ClusterDeployment{},
+			cpr: &hivev1.ClusterProvision{
+				Spec: hivev1.ClusterProvisionSpec{
+					InstallLog: installLog("level=info msg=\"Waiting up to 40m0s for bootstrapping to complete...\"\n" +
+						"level=fatal msg=\"failed to wait for bootstrapping to complete: timed out waiting for the condition\""),
+				},
+			},
+			wantCategory: FailureCategoryBootstrapTimeout,
+		},
+		{
+			name: "unknown",
+			cd:   &hivev1.ClusterDeployment{},
+			cpr: &hivev1.ClusterProvision{
+				Status: hivev1.ClusterProvisionStatus{
+					Conditions: []hivev1.ClusterProvisionCondition{
+						{
+							Type:    hivev1.ClusterProvisionFailedCondition,
+							Status:  corev1.ConditionTrue,
+							Reason:  "UnknownError",
+							Message: "something went wrong",
+						},
+					},
+				},
+			},
+			wantCategory: FailureCategoryUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyFailure(tt.cd, tt.cpr)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("ClassifyFailure() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("ClassifyFailure() = nil, want %s", tt.wantCategory)
+			}
+			if got.Category != tt.wantCategory {
+				t.Errorf("ClassifyFailure() category = %s, want %s (message: %s)", got.Category, tt.wantCategory, got.Message)
+			}
+			if got.Stopped != tt.wantStopped {
+				t.Errorf("ClassifyFailure() stopped = %t, want %t", got.Stopped, tt.wantStopped)
+			}
+			if len(got.Hint) == 0 {
+				t.Error("ClassifyFailure() hint is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// RunHooks runs the hooks of a phase for each clusterdeployment.
+// Before running the post-hibernate and post-run hooks, it waits until the clusters reach
+// the power state for at most timeout minutes.
+// The hooks of a running cluster use the admin kubeconfig of the clusterdeployment.
+func RunHooks(kubeClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	clusterNames []string,
+	hooks *helpers.Hooks,
+	phase helpers.HookPhase,
+	timeout int,
+	force, dryRun bool) error {
+	phaseHooks := hooks.Get(phase)
+	if len(phaseHooks) == 0 {
+		return nil
+	}
+	if !dryRun {
+		var err error
+		switch phase {
+		case helpers.HookPhasePostHibernate:
+			err = WaitPowerState(dynamicClient, clusterNames, hivev1.ClusterPowerStateHibernating, timeout)
+		case helpers.HookPhasePostRun:
+			err = WaitPowerState(dynamicClient, clusterNames, hivev1.ClusterPowerStateRunning, timeout)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, clusterName := range clusterNames {
+		var kubeConfig *clientcmdapi.Config
+		if phase.ClusterRunning() && !dryRun {
+			var err error
+			kubeConfig, err = GetAdminKubeConfig(kubeClient, dynamicClient, clusterName)
+			if err != nil {
+				err = fmt.Errorf("%s hooks can not get the kubeconfig of cluster %s: %v", phase, clusterName, err)
+				if !force {
+					return fmt.Errorf("%v, use --force to ignore the hook failures", err)
+				}
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+		if err := helpers.RunHooks(phaseHooks, phase, clusterName, kubeConfig, force, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAdminKubeConfig returns the admin kubeconfig of a clusterdeployment
+func GetAdminKubeConfig(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, clusterName string) (*clientcmdapi.Config, error) {
+	cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(clusterName).Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cd := &hivev1.ClusterDeployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cdu.UnstructuredContent(), cd); err != nil {
+		return nil, err
+	}
+	if cd.Spec.ClusterMetadata == nil {
+		return nil, fmt.Errorf("the clusterdeployment %s is not installed", clusterName)
+	}
+	s, err := kubeClient.CoreV1().
+		Secrets(cd.Namespace).
+		Get(context.TODO(), cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.Load(s.Data["kubeconfig"])
+}
@@ -0,0 +1,235 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ClusterDeploymentNameLabel is set by hive on the objects created for a clusterdeployment
+	ClusterDeploymentNameLabel = "hive.openshift.io/cluster-deployment-name"
+	// ClusterProvisionNameLabel is set by hive on the install job and pod
+	ClusterProvisionNameLabel = "hive.openshift.io/cluster-provision-name"
+	// UninstallJobLabel is set by hive on the uninstall job and pod
+	UninstallJobLabel = "hive.openshift.io/uninstall"
+
+	installContainerName     = "hive"
+	deprovisionContainerName = "deprovision"
+	// maxInstallerErrors is the number of installer error lines kept by ExtractInstallerErrors
+	maxInstallerErrors = 10
+)
+
+var installerErrorRegexp = regexp.MustCompile(`level=(error|fatal)`)
+
+// LogsOptions defines which logs to retrieve and how
+type LogsOptions struct {
+	// If true the deprovision logs are retrieved instead of the provision logs
+	Deprovision bool
+	// If true the logs are streamed until the pod terminates
+	Follow bool
+	// Number of lines to display from the end of the logs, all if negative
+	TailLines int64
+	// If true only the installer error lines are displayed
+	ErrorsOnly bool
+	// If set the logs are saved in that file
+	OutputFile string
+}
+
+// GetClusterProvisions returns the clusterprovisions of a clusterdeployment sorted by attempt
+func GetClusterProvisions(dynamicClient dynamic.Interface, clusterName string) ([]hivev1.ClusterProvision, error) {
+	cprl, err := dynamicClient.Resource(helpers.GvrCPR).Namespace(clusterName).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ClusterDeploymentNameLabel, clusterName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cprs := make([]hivev1.ClusterProvision, 0)
+	for _, cpru := range cprl.Items {
+		cpr := hivev1.ClusterProvision{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cpru.UnstructuredContent(), &cpr); err != nil {
+			return nil, err
+		}
+		cprs = append(cprs, cpr)
+	}
+	sort.Slice(cprs, func(i, j int) bool {
+		return cprs[i].Spec.Attempt < cprs[j].Spec.Attempt
+	})
+	return cprs, nil
+}
+
+// GetLatestClusterProvision returns the last clusterprovision of a clusterdeployment
+func GetLatestClusterProvision(dynamicClient dynamic.Interface, clusterName string) (*hivev1.ClusterProvision, error) {
+	cprs, err := GetClusterProvisions(dynamicClient, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(cprs) == 0 {
+		return nil, fmt.Errorf("no clusterprovision found for cluster %s", clusterName)
+	}
+	return &cprs[len(cprs)-1], nil
+}
+
+// GetClusterDeprovision returns the clusterdeprovision of a clusterdeployment
+func GetClusterDeprovision(dynamicClient dynamic.Interface, clusterName string) (*hivev1.ClusterDeprovision, error) {
+	cdpru, err := dynamicClient.Resource(helpers.GvrCDPR).Namespace(clusterName).Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("no clusterdeprovision found for cluster %s", clusterName)
+		}
+		return nil, err
+	}
+	cdpr := &hivev1.ClusterDeprovision{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cdpru.UnstructuredContent(), cdpr); err != nil {
+		return nil, err
+	}
+	return cdpr, nil
+}
+
+// WriteLogs writes the logs of the latest provision or of the deprovision of a clusterdeployment
+// to out and the status lines to errOut.
+// The clusterName is the name of the clusterdeployment and of its namespace.
+func WriteLogs(dynamicClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
+	clusterName string,
+	o *LogsOptions,
+	out, errOut io.Writer) error {
+	var selector, container, fallbackLog string
+	if o.Deprovision {
+		cdpr, err := GetClusterDeprovision(dynamicClient, clusterName)
+		if err != nil {
+			return err
+		}
+		selector = fmt.Sprintf("%s=true,%s=%s", UninstallJobLabel, ClusterDeploymentNameLabel, clusterName)
+		container = deprovisionContainerName
+		fmt.Fprintf(errOut, "clusterdeprovision %s (completed: %t)\n", cdpr.Name, cdpr.Status.Completed)
+	} else {
+		cpr, err := GetLatestClusterProvision(dynamicClient, clusterName)
+		if err != nil {
+			return err
+		}
+		selector = fmt.Sprintf("%s=%s", ClusterProvisionNameLabel, cpr.Name)
+		container = installContainerName
+		if cpr.Spec.InstallLog != nil {
+			fallbackLog = *cpr.Spec.InstallLog
+		}
+		fmt.Fprintf(errOut, "clusterprovision %s (attempt: %d, stage: %s)\n", cpr.Name, cpr.Spec.Attempt, cpr.Spec.Stage)
+	}
+
+	pod, err := getLatestPod(kubeClient, clusterName, selector)
+	if err != nil {
+		return err
+	}
+
+	if pod == nil {
+		if len(fallbackLog) == 0 {
+			return fmt.Errorf("no pod found in namespace %s with labels %s", clusterName, selector)
+		}
+		fmt.Fprintf(errOut, "install pod not found, displaying the install log stored in the clusterprovision\n")
+		if o.TailLines >= 0 && !o.ErrorsOnly {
+			fallbackLog = tail(fallbackLog, o.TailLines)
+		}
+		return o.write(strings.NewReader(fallbackLog), out)
+	}
+
+	podLogOptions := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    o.Follow,
+	}
+	if o.TailLines >= 0 && !o.ErrorsOnly {
+		podLogOptions.TailLines = &o.TailLines
+	}
+	stream, err := kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, podLogOptions).Stream(context.TODO())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	return o.write(stream, out)
+}
+
+func (o *LogsOptions) write(r io.Reader, out io.Writer) error {
+	if len(o.OutputFile) != 0 {
+		f, err := os.Create(filepath.Clean(o.OutputFile))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if !o.ErrorsOnly {
+		_, err := io.Copy(out, r)
+		return err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	for _, l := range ExtractInstallerErrors(string(b)) {
+		fmt.Fprintln(out, l)
+	}
+	return nil
+}
+
+// tail returns the last lines of a log
+func tail(log string, lines int64) string {
+	l := strings.SplitAfter(log, "\n")
+	// a log ending with a new line has an empty last element
+	if len(l) != 0 && len(l[len(l)-1]) == 0 {
+		l = l[:len(l)-1]
+	}
+	if int64(len(l)) > lines {
+		l = l[int64(len(l))-lines:]
+	}
+	return strings.Join(l, "")
+}
+
+func getLatestPod(kubeClient kubernetes.Interface, namespace, selector string) (*corev1.Pod, error) {
+	pl, err := kubeClient.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pl.Items) == 0 {
+		return nil, nil
+	}
+	sort.Slice(pl.Items, func(i, j int) bool {
+		return pl.Items[j].CreationTimestamp.Before(&pl.Items[i].CreationTimestamp)
+	})
+	return &pl.Items[0], nil
+}
+
+// ExtractInstallerErrors returns the last error and fatal lines of an installer log
+func ExtractInstallerErrors(log string) []string {
+	errorLines := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewBufferString(log))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l := strings.TrimSpace(scanner.Text())
+		if installerErrorRegexp.MatchString(l) {
+			errorLines = append(errorLines, l)
+		}
+	}
+	if len(errorLines) > maxInstallerErrors {
+		errorLines = errorLines[len(errorLines)-maxInstallerErrors:]
+	}
+	return errorLines
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExtractInstallerErrors(t *testing.T) {
+	manyErrors := make([]string, 0)
+	want := make([]string, 0)
+	for i := 0; i < maxInstallerErrors+2; i++ {
+		l := fmt.Sprintf("level=error msg=\"error %d\"", i)
+		manyErrors = append(manyErrors, l)
+		if i >= 2 {
+			want = append(want, l)
+		}
+	}
+	tests := []struct {
+		name string
+		log  string
+		want []string
+	}{
+		{
+			name: "no errors",
+			log:  "level=info msg=\"Creating infrastructure resources...\"\nlevel=debug msg=\"done\"",
+			want: []string{},
+		},
+		{
+			name: "error and fatal",
+			log: "level=info msg=\"Waiting up to 40m0s for bootstrapping to complete...\"\n" +
+				"level=error msg=\"Bootstrap failed to complete\"\n" +
+				"level=fatal msg=\"failed to wait for bootstrapping to complete\"",
+			want: []string{
+				"level=error msg=\"Bootstrap failed to complete\"",
+				"level=fatal msg=\"failed to wait for bootstrapping to complete\"",
+			},
+		},
+		{
+			name: "keep last errors",
+			log:  strings.Join(manyErrors, "\n"),
+			want: want,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractInstallerErrors(tt.log); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractInstallerErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteLogsFromClusterProvision(t *testing.T) {
+	installLog := "level=info msg=\"Creating infrastructure resources...\"\nlevel=fatal msg=\"error creating dns record\"\n"
+	cpr := &hivev1.ClusterProvision{
+		TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterProvision"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mycluster-0-abcde",
+			Namespace: "mycluster",
+			Labels:    map[string]string{ClusterDeploymentNameLabel: "mycluster"},
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			Stage:      hivev1.ClusterProvisionStageFailed,
+			InstallLog: &installLog,
+		},
+	}
+	cpru, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cpr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helpers.GvrCPR: "ClusterProvisionList",
+		},
+		&unstructured.Unstructured{Object: cpru})
+	kubeClient := fakekubernetes.NewSimpleClientset()
+
+	var out, errOut bytes.Buffer
+	if err := WriteLogs(dynamicClient, kubeClient, "mycluster", &LogsOptions{TailLines: -1}, &out, &errOut); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != installLog {
+		t.Errorf("got %q, want %q", out.String(), installLog)
+	}
+	if !strings.Contains(errOut.String(), "clusterprovision mycluster-0-abcde") ||
+		!strings.Contains(errOut.String(), "install pod not found") {
+		t.Errorf("expected the status lines on errOut, got %q", errOut.String())
+	}
+
+	out.Reset()
+	if err := WriteLogs(dynamicClient, kubeClient, "mycluster", &LogsOptions{TailLines: 1}, &out, &errOut); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "level=fatal msg=\"error creating dns record\"\n" {
+		t.Errorf("expected the last line of the install log, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := WriteLogs(dynamicClient, kubeClient, "mycluster", &LogsOptions{ErrorsOnly: true}, &out, &errOut); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "level=fatal msg=\"error creating dns record\"\n" {
+		t.Errorf("got %q", out.String())
+	}
+
+	if err := WriteLogs(dynamicClient, kubeClient, "othercluster", &LogsOptions{}, &out, &errOut); err == nil {
+		t.Error("expected an error when no clusterprovision exists")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const powerStateInterval = 10 * time.Second
+
+// PowerStateStatus is the progress of a clusterdeployment toward a power state
+type PowerStateStatus struct {
+	// Phase is the status.powerState of the clusterdeployment
+	Phase string
+	// Reason is the reason and message of the Hibernating or Ready condition
+	Reason string
+	// Done is true when the clusterdeployment reached the power state
+	Done bool
+	// Err is set when the power state can not be reached
+	Err error
+}
+
+// GetPowerStateStatus returns the progress of a clusterdeployment toward the powerState
+func GetPowerStateStatus(cd *hivev1.ClusterDeployment, powerState hivev1.ClusterPowerState) *PowerStateStatus {
+	s := &PowerStateStatus{
+		Phase: string(cd.Status.PowerState),
+	}
+	if len(s.Phase) == 0 {
+		s.Phase = "Pending"
+	}
+	hibernating := getCondition(cd, hivev1.ClusterHibernatingCondition)
+	ready := getCondition(cd, hivev1.ClusterReadyCondition)
+	// The Unsupported reason is set whatever the requested power state is,
+	// it only prevents the hibernation.
+	if powerState == hivev1.ClusterPowerStateHibernating &&
+		hibernating != nil && hibernating.Reason == hivev1.HibernatingReasonUnsupported {
+		s.Reason = conditionReason(hibernating)
+		s.Err = fmt.Errorf("hibernation is not supported: %s", hibernating.Message)
+		return s
+	}
+	switch powerState {
+	case hivev1.ClusterPowerStateHibernating:
+		s.Reason = conditionReason(hibernating)
+		s.Done = cd.Status.PowerState == hivev1.ClusterPowerStateHibernating ||
+			(hibernating != nil && hibernating.Status == corev1.ConditionTrue)
+	case hivev1.ClusterPowerStateRunning:
+		s.Reason = conditionReason(ready)
+		s.Done = cd.Status.PowerState == hivev1.ClusterPowerStateRunning ||
+			(ready != nil && ready.Status == corev1.ConditionTrue)
+	}
+	return s
+}
+
+// WaitPowerState waits until the clusterdeployments reach the powerState or the timeout in minutes expires.
+// The clusters which can not reach the power state are reported in the returned error.
+func WaitPowerState(dynamicClient dynamic.Interface,
+	clusterNames []string,
+	powerState hivev1.ClusterPowerState,
+	timeout int) error {
+	progress := helpers.NewProgress(os.Stdout)
+	defer progress.Stop()
+	start := time.Now()
+	statuses := make(map[string]*PowerStateStatus)
+	err := wait.PollImmediate(powerStateInterval, time.Duration(timeout)*time.Minute, func() (bool, error) {
+		return checkPowerState(dynamicClient, clusterNames, powerState, statuses, progress)
+	})
+	errs := make([]string, 0)
+	for _, clusterName := range clusterNames {
+		s, ok := statuses[clusterName]
+		switch {
+		case ok && s.Err != nil:
+			errs = append(errs, fmt.Sprintf("cluster %s: %v", clusterName, s.Err))
+		case ok && s.Done:
+		case err == wait.ErrWaitTimeout:
+			msg := fmt.Sprintf("cluster %s: timed out waiting for %s", clusterName, powerState)
+			if ok {
+				msg = fmt.Sprintf("%s, current power state %s", msg, s.Phase)
+				if len(s.Reason) != 0 {
+					msg = fmt.Sprintf("%s (%s)", msg, s.Reason)
+				}
+			}
+			errs = append(errs, msg)
+		}
+	}
+	if err != nil && err != wait.ErrWaitTimeout {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) != 0 {
+		err = fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	helpers.NotifyWaitDone(fmt.Sprintf("cluster %s %s", strings.Join(clusterNames, ","), powerState), start, err)
+	return err
+}
+
+// checkPowerState updates the statuses of the clusterdeployments which are not yet done or failed
+// and returns true when all of them are done or failed.
+func checkPowerState(dynamicClient dynamic.Interface,
+	clusterNames []string,
+	powerState hivev1.ClusterPowerState,
+	statuses map[string]*PowerStateStatus,
+	progress *helpers.Progress) (bool, error) {
+	completed := true
+	for _, clusterName := range clusterNames {
+		if s, ok := statuses[clusterName]; ok && (s.Done || s.Err != nil) {
+			continue
+		}
+		cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(clusterName).Get(context.TODO(), clusterName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		cd := &hivev1.ClusterDeployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cdu.UnstructuredContent(), cd); err != nil {
+			return false, err
+		}
+		s := GetPowerStateStatus(cd, powerState)
+		statuses[clusterName] = s
+		switch {
+		case s.Err != nil:
+			progress.Done(clusterName, s.Phase, s.Err.Error())
+		case s.Done:
+			progress.Done(clusterName, s.Phase, s.Reason)
+		default:
+			progress.Update(clusterName, s.Phase, s.Reason)
+			completed = false
+		}
+	}
+	return completed, nil
+}
+
+func getCondition(cd *hivev1.ClusterDeployment, conditionType hivev1.ClusterDeploymentConditionType) *hivev1.ClusterDeploymentCondition {
+	for i := range cd.Status.Conditions {
+		if cd.Status.Conditions[i].Type == conditionType {
+			return &cd.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func conditionReason(c *hivev1.ClusterDeploymentCondition) string {
+	if c == nil {
+		return ""
+	}
+	if len(c.Message) == 0 {
+		return c.Reason
+	}
+	return fmt.Sprintf("%s: %s", c.Reason, c.Message)
+}
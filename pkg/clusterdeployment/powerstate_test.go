@@ -0,0 +1,146 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterdeployment
+
+import (
+	"strings"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func newPowerStateCD(name string, powerState hivev1.ClusterPowerState, conditions ...hivev1.ClusterDeploymentCondition) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+			Kind:       "ClusterDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: name,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			PowerState: powerState,
+			Conditions: conditions,
+		},
+	}
+}
+
+func TestGetPowerStateStatus(t *testing.T) {
+	unsupported := hivev1.ClusterDeploymentCondition{
+		Type:    hivev1.ClusterHibernatingCondition,
+		Status:  corev1.ConditionFalse,
+		Reason:  hivev1.HibernatingReasonUnsupported,
+		Message: "Unsupported platform: no actuator to handle it",
+	}
+	tests := []struct {
+		name       string
+		cd         *hivev1.ClusterDeployment
+		powerState hivev1.ClusterPowerState
+		wantPhase  string
+		wantReason string
+		wantDone   bool
+		wantErr    bool
+	}{
+		{
+			name:       "no status",
+			cd:         newPowerStateCD("c1", ""),
+			powerState: hivev1.ClusterPowerStateHibernating,
+			wantPhase:  "Pending",
+		},
+		{
+			name: "stopping",
+			cd: newPowerStateCD("c1", hivev1.ClusterPowerStateStopping, hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionFalse,
+				Reason: hivev1.HibernatingReasonStopping,
+			}),
+			powerState: hivev1.ClusterPowerStateHibernating,
+			wantPhase:  "Stopping",
+			wantReason: "Stopping",
+		},
+		{
+			name: "hibernating",
+			cd: newPowerStateCD("c1", hivev1.ClusterPowerStateHibernating, hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: corev1.ConditionTrue,
+				Reason: hivev1.HibernatingReasonHibernating,
+			}),
+			powerState: hivev1.ClusterPowerStateHibernating,
+			wantPhase:  "Hibernating",
+			wantReason: "Hibernating",
+			wantDone:   true,
+		},
+		{
+			name:       "unsupported hibernation",
+			cd:         newPowerStateCD("c1", hivev1.ClusterPowerStateRunning, unsupported),
+			powerState: hivev1.ClusterPowerStateHibernating,
+			wantPhase:  "Running",
+			wantReason: "Unsupported: Unsupported platform: no actuator to handle it",
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported hibernation does not prevent running",
+			cd:         newPowerStateCD("c1", hivev1.ClusterPowerStateRunning, unsupported),
+			powerState: hivev1.ClusterPowerStateRunning,
+			wantPhase:  "Running",
+			wantDone:   true,
+		},
+		{
+			name: "failed to start machines",
+			cd: newPowerStateCD("c1", hivev1.ClusterPowerStateFailedToStartMachines, hivev1.ClusterDeploymentCondition{
+				Type:    hivev1.ClusterReadyCondition,
+				Status:  corev1.ConditionFalse,
+				Reason:  hivev1.ReadyReasonFailedToStartMachines,
+				Message: "InsufficientInstanceCapacity",
+			}),
+			powerState: hivev1.ClusterPowerStateRunning,
+			wantPhase:  "FailedToStartMachines",
+			wantReason: "FailedToStartMachines: InsufficientInstanceCapacity",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := GetPowerStateStatus(tt.cd, tt.powerState)
+			if s.Phase != tt.wantPhase || s.Reason != tt.wantReason || s.Done != tt.wantDone || (s.Err != nil) != tt.wantErr {
+				t.Errorf("GetPowerStateStatus() = %+v", s)
+			}
+		})
+	}
+}
+
+func TestWaitPowerState(t *testing.T) {
+	objs := make([]runtime.Object, 0)
+	for _, cd := range []*hivev1.ClusterDeployment{
+		newPowerStateCD("hibernated", hivev1.ClusterPowerStateHibernating),
+		newPowerStateCD("unsupported", hivev1.ClusterPowerStateRunning, hivev1.ClusterDeploymentCondition{
+			Type:    hivev1.ClusterHibernatingCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  hivev1.HibernatingReasonUnsupported,
+			Message: "Unsupported platform: no actuator to handle it",
+		}),
+	} {
+		cdu, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: cdu})
+	}
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objs...)
+
+	if err := WaitPowerState(dynamicClient, []string{"hibernated"}, hivev1.ClusterPowerStateHibernating, 1); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	err := WaitPowerState(dynamicClient, []string{"hibernated", "unsupported"}, hivev1.ClusterPowerStateHibernating, 1)
+	if err == nil {
+		t.Fatal("expected an error for the unsupported cluster")
+	}
+	if !strings.HasPrefix(err.Error(), "cluster unsupported: hibernation is not supported") ||
+		strings.Contains(err.Error(), "cluster hibernated") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
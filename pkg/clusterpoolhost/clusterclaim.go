@@ -4,6 +4,8 @@ package clusterpoolhost
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -11,8 +13,10 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/stolostron/applier/pkg/apply"
 	printclusterpoolv1alpha1 "github.com/stolostron/cm-cli/api/cm-cli/v1alpha1"
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
 	"github.com/stolostron/cm-cli/pkg/clusterpoolhost/scenario"
 	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/version"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,11 +32,24 @@ import (
 
 const clusterClaimErrorFormat = "(%d/%d) clusterclaim %s error: %s"
 
+var clusterClaimErrorPrefixRegexp = regexp.MustCompile(`^\(\d+(/\d+)?\) clusterclaim \S+ `)
+
+// provisionStoppedError is returned when hive stopped provisioning the claimed cluster
+type provisionStoppedError struct {
+	error
+}
+
 func (cph *ClusterPoolHost) GetClusterContextName(clusterName string) string {
 	return fmt.Sprintf("%s/%s", cph.Name, clusterName)
 }
 
-func (cph *ClusterPoolHost) CreateClusterClaims(clusterClaimNames, clusterPoolName string, autoImport bool, timeout int, dryRun bool, outputFile string, printFlags *get.PrintFlags) error {
+func (cph *ClusterPoolHost) CreateClusterClaims(clusterClaimNames, clusterPoolName string,
+	autoImport bool,
+	metadata *ClusterClaimMetadata,
+	timeout int,
+	dryRun bool,
+	outputFile string,
+	printFlags *get.PrintFlags) error {
 	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
 	if err != nil {
 		return err
@@ -82,6 +99,12 @@ func (cph *ClusterPoolHost) CreateClusterClaims(clusterClaimNames, clusterPoolNa
 		values["AutoImport"] = strconv.FormatBool(autoImport)
 		values["ServiceAccountName"] = serviceAccountName
 		values["Group"] = cph.Group
+		values["Creator"] = serviceAccountName
+		values["CreatorVersion"] = version.GetVersion()
+		if metadata != nil {
+			values["Description"] = metadata.Description
+			values["Ticket"] = metadata.Ticket
+		}
 		files := []string{
 			"create/clusterclaim/clusterclaim_cr.yaml",
 		}
@@ -104,7 +127,16 @@ func (cph *ClusterPoolHost) CreateClusterClaims(clusterClaimNames, clusterPoolNa
 	return apply.WriteOutput(outputFile, output)
 }
 
-func (cph *ClusterPoolHost) RunClusterClaims(clusterClaimNames string, scheduleSkip string, timeout int, dryRun bool, outputFile string, printFlags *get.PrintFlags) error {
+func (cph *ClusterPoolHost) RunClusterClaims(clusterClaimNames string,
+	scheduleSkip string,
+	hooks *helpers.Hooks,
+	timeout int,
+	force, dryRun bool,
+	outputFile string,
+	printFlags *get.PrintFlags) error {
+	if err := cph.RunClusterClaimsHooks(clusterClaimNames, hooks, helpers.HookPhasePreRun, timeout, force, dryRun); err != nil {
+		return err
+	}
 	if err := cph.setHibernateClusterClaims(clusterClaimNames, false, dryRun); err != nil {
 		return err
 	}
@@ -122,17 +154,44 @@ func (cph *ClusterPoolHost) RunClusterClaims(clusterClaimNames string, scheduleS
 		if err != nil {
 			return err
 		}
-		return waitClusterClaimsRunning(dynamicClient, clusterClaimNames, "", cph.Namespace, timeout, printFlags)
+		if err := waitClusterClaimsRunning(dynamicClient, clusterClaimNames, "", cph.Namespace, timeout, printFlags); err != nil {
+			return err
+		}
 	}
-	return nil
+	return cph.RunClusterClaimsHooks(clusterClaimNames, hooks, helpers.HookPhasePostRun, timeout, force, dryRun)
 }
 
-func (cph *ClusterPoolHost) HibernateClusterClaims(clusterClaimNames string, scheduleSkip string, dryRun bool) error {
-	err := cph.setHibernateClusterClaims(clusterClaimNames, true, dryRun)
+func (cph *ClusterPoolHost) HibernateClusterClaims(clusterClaimNames string,
+	scheduleSkip string,
+	hooks *helpers.Hooks,
+	timeout int,
+	force, dryRun bool) error {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := cph.checkClusterClaimsLease(dynamicClient, clusterPoolRestConfig, clusterClaimNames, force); err != nil {
+		return err
+	}
+
+	if err := cph.RunClusterClaimsHooks(clusterClaimNames, hooks, helpers.HookPhasePreHibernate, timeout, force, dryRun); err != nil {
+		return err
+	}
+
+	err = cph.setHibernateClusterClaims(clusterClaimNames, true, dryRun)
 	if err != nil {
 		return err
 	}
-	return cph.SetHibernateScheduleClusterClaims(clusterClaimNames, scheduleSkip, dryRun)
+	if err := cph.SetHibernateScheduleClusterClaims(clusterClaimNames, scheduleSkip, dryRun); err != nil {
+		return err
+	}
+	return cph.RunClusterClaimsHooks(clusterClaimNames, hooks, helpers.HookPhasePostHibernate, timeout, force, dryRun)
 }
 
 func (cph *ClusterPoolHost) SetHibernateScheduleClusterClaims(clusterClaimNames string, scheduleSkip string, dryRun bool) error {
@@ -240,8 +299,12 @@ func (cph *ClusterPoolHost) setHibernateClusterClaims(clusterClaimNames string,
 }
 
 func waitClusterClaimsRunning(dynamicClient dynamic.Interface, clusterClaimNames, clusterPoolName, namespace string, timeout int, printFlags *get.PrintFlags) error {
+	// the warning is printed once, before the progress starts rendering in place
+	if err := warnClusterPoolSize(dynamicClient, clusterPoolName, namespace); err != nil {
+		return err
+	}
 	if timeout == 0 {
-		running, err := checkClusterClaimsRunning(dynamicClient, clusterClaimNames, clusterPoolName, namespace, 0, timeout, false, printFlags)
+		running, err := checkClusterClaimsRunning(dynamicClient, clusterClaimNames, namespace, 0, timeout, false, printFlags, nil)
 		if err != nil {
 			return err
 		}
@@ -250,32 +313,60 @@ func waitClusterClaimsRunning(dynamicClient dynamic.Interface, clusterClaimNames
 		}
 		return nil
 	}
-	i := 0
-	return wait.PollImmediate(1*time.Minute, time.Duration(timeout)*time.Minute, func() (bool, error) {
-		running, err := checkClusterClaimsRunning(dynamicClient, clusterClaimNames, clusterPoolName, namespace, i, timeout, false, printFlags)
-		i += 1
-		return running, err
+	interval := 1 * time.Minute
+	var progress *helpers.Progress
+	if isHumanReadableOutput(printFlags) {
+		progress = helpers.NewProgress(os.Stdout)
+		defer progress.Stop()
+		interval = 15 * time.Second
+	}
+	start := time.Now()
+	err := wait.PollImmediate(interval, time.Duration(timeout)*time.Minute, func() (bool, error) {
+		// the counter is in minutes like the timeout, whatever the poll interval
+		elapsed := int(time.Since(start) / time.Minute)
+		return checkClusterClaimsRunning(dynamicClient, clusterClaimNames, namespace, elapsed, timeout, false, printFlags, progress)
 	})
+	helpers.NotifyWaitDone(fmt.Sprintf("clusterclaim %s", clusterClaimNames), start, err)
+	return err
 
 }
 
-func checkClusterClaimsRunning(dynamicClient dynamic.Interface, clusterClaimNames, clusterPoolName, namespace string, i, timeout int, errorOnHibernate bool, printFlags *get.PrintFlags) (bool, error) {
-	if len(clusterPoolName) != 0 {
-		cpu, err := dynamicClient.Resource(helpers.GvrCP).Namespace(namespace).Get(context.TODO(), clusterPoolName, metav1.GetOptions{})
-		if err != nil {
-			return false, err
-		}
-		cp := &hivev1.ClusterPool{}
-		err = runtime.DefaultUnstructuredConverter.FromUnstructured(cpu.UnstructuredContent(), cp)
-		if err != nil {
-			return false, err
-		}
-		if cp.Spec.Size == 0 {
-			fmt.Printf("WARNING: the clusterpool %s size is 0, should be at least 1 for the clusterclaim to be honored\n", clusterPoolName)
-		}
+// warnClusterPoolSize prints a warning if the clusterpool has a size of 0, the clusterclaims are then not honored
+func warnClusterPoolSize(dynamicClient dynamic.Interface, clusterPoolName, namespace string) error {
+	if len(clusterPoolName) == 0 {
+		return nil
+	}
+	cpu, err := dynamicClient.Resource(helpers.GvrCP).Namespace(namespace).Get(context.TODO(), clusterPoolName, metav1.GetOptions{})
+	if err != nil {
+		return err
 	}
+	cp := &hivev1.ClusterPool{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(cpu.UnstructuredContent(), cp)
+	if err != nil {
+		return err
+	}
+	if cp.Spec.Size == 0 {
+		fmt.Printf("WARNING: the clusterpool %s size is 0, should be at least 1 for the clusterclaim to be honored\n", clusterPoolName)
+	}
+	return nil
+}
+
+func isHumanReadableOutput(printFlags *get.PrintFlags) bool {
+	return printFlags == nil || printFlags.OutputFormat == nil || strings.HasPrefix(*printFlags.OutputFormat, "custom-columns=")
+}
+
+// checkClusterClaimsRunning checks if the clusterclaims are running,
+// the status of each clusterclaim is rendered by the progress if not nil, printed otherwise.
+func checkClusterClaimsRunning(dynamicClient dynamic.Interface,
+	clusterClaimNames, namespace string,
+	i, timeout int,
+	errorOnHibernate bool,
+	printFlags *get.PrintFlags,
+	progress *helpers.Progress) (bool, error) {
 	allErrors := make(map[string]error)
 	allRunning := true
+	stopped := make([]string, 0)
+	phases := make(map[string][2]string)
 	for _, ccn := range strings.Split(clusterClaimNames, ",") {
 		clusterClaimName := strings.TrimSpace(ccn)
 		ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(namespace).Get(context.TODO(), clusterClaimName, metav1.GetOptions{})
@@ -289,6 +380,10 @@ func checkClusterClaimsRunning(dynamicClient dynamic.Interface, clusterClaimName
 			allErrors[clusterClaimName] = fmt.Errorf(clusterClaimErrorFormat, i, timeout, clusterClaimName, err.Error())
 			continue
 		}
+		if progress != nil {
+			phase, reason := getClusterClaimPhase(dynamicClient, cc)
+			phases[clusterClaimName] = [2]string{phase, reason}
+		}
 		running, allErrorsO, err := checkClusterClaimRunning(dynamicClient,
 			clusterClaimName,
 			namespace,
@@ -298,20 +393,30 @@ func checkClusterClaimsRunning(dynamicClient dynamic.Interface, clusterClaimName
 			printFlags)
 		if err != nil {
 			allErrors[clusterClaimName] = err
+			if _, ok := err.(*provisionStoppedError); ok {
+				stopped = append(stopped, clusterClaimName)
+			}
 		}
 		for k, v := range allErrorsO {
 			allErrors[k] = v
 		}
 		if !running {
+			allRunning = false
+			if _, ok := allErrors[clusterClaimName]; ok {
+				continue
+			}
 			if timeout == 0 {
 				allErrors[clusterClaimName] = fmt.Errorf("(%d) clusterclaim %s is not running", i, clusterClaimName)
 			} else {
 				allErrors[clusterClaimName] = fmt.Errorf("(%d/%d) clusterclaim %s is not running", i, timeout, clusterClaimName)
 			}
-			allRunning = false
 		} else {
 			delete(allErrors, clusterClaimName)
-			if printFlags == nil || printFlags.OutputFormat == nil || strings.HasPrefix(*printFlags.OutputFormat, "custom-columns=") {
+			if progress != nil {
+				progress.Done(clusterClaimName, "Running", fmt.Sprintf("id %s", cc.Spec.Namespace))
+				continue
+			}
+			if isHumanReadableOutput(printFlags) {
 				if timeout == 0 {
 					fmt.Printf("(%d) clusterclaim %s is running with id %s\n", i, clusterClaimName, cc.Spec.Namespace)
 				} else {
@@ -320,8 +425,26 @@ func checkClusterClaimsRunning(dynamicClient dynamic.Interface, clusterClaimName
 			}
 		}
 	}
-	for _, msg := range allErrors {
-		fmt.Println(msg)
+	for name, msg := range allErrors {
+		if progress == nil {
+			fmt.Println(msg)
+			continue
+		}
+		if _, ok := msg.(*provisionStoppedError); ok {
+			progress.Done(name, "ProvisionStopped", trimClusterClaimErrorPrefix(msg.Error()))
+			continue
+		}
+		phase, reason := "Unknown", trimClusterClaimErrorPrefix(msg.Error())
+		if pr, ok := phases[name]; ok {
+			phase = pr[0]
+			if strings.HasSuffix(reason, "is not running") {
+				reason = pr[1]
+			}
+		}
+		progress.Update(name, phase, reason)
+	}
+	if len(stopped) != 0 {
+		return false, fmt.Errorf("provisioning stopped for clusterclaims: %s", strings.Join(stopped, ","))
 	}
 	if len(allErrors) == 0 {
 		return allRunning, nil
@@ -368,6 +491,17 @@ func checkClusterClaimRunning(dynamicClient dynamic.Interface,
 					" run a \"cm use cc\" or \"cm run cc\" command to resume it",
 					i, timeout, cc.GetName())
 		}
+		if f := clusterdeployment.GetClusterDeploymentFailure(dynamicClient, cd); f != nil {
+			if f.Stopped {
+				return running,
+					allErrors,
+					&provisionStoppedError{
+						fmt.Errorf(clusterClaimErrorFormat+"\nhint: %s", i, timeout, clusterClaimName, f.String(), f.Hint),
+					}
+			}
+			allErrors[clusterClaimName] = fmt.Errorf("(%d/%d) clusterclaim %s provision is failing, hive will retry. %s\nhint: %s",
+				i, timeout, clusterClaimName, f.String(), f.Hint)
+		}
 		c := getClusterClaimRunningStatus(cc)
 		if len(cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name) != 0 &&
 			len(cd.Spec.BaseDomain) != 0 &&
@@ -388,7 +522,7 @@ func getClusterClaimRunningStatus(cc *hivev1.ClusterClaim) *hivev1.ClusterClaimC
 	return nil
 }
 
-func (cph *ClusterPoolHost) DeleteClusterClaims(clusterClaimNames string, dryRun bool) error {
+func (cph *ClusterPoolHost) DeleteClusterClaims(clusterClaimNames string, force, dryRun bool) error {
 	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
 	if err != nil {
 		return err
@@ -399,6 +533,10 @@ func (cph *ClusterPoolHost) DeleteClusterClaims(clusterClaimNames string, dryRun
 		return err
 	}
 
+	if err := cph.checkClusterClaimsLease(dynamicClient, clusterPoolRestConfig, clusterClaimNames, force); err != nil {
+		return err
+	}
+
 	for _, ccn := range strings.Split(clusterClaimNames, ",") {
 		clusterClaimName := strings.TrimSpace(ccn)
 		if !dryRun {
@@ -464,8 +602,17 @@ func (cph *ClusterPoolHost) ConvertToPrintClusterClaimList(ccl *hivev1.ClusterCl
 				ClusterPoolHostName: cph.Name,
 				ClusterClaim:        &ccl.Items[i],
 				Age:                 helpers.TimeDiff(ccl.Items[i].CreationTimestamp.Time, time.Second),
+				Subjects:            FormatSubjects(ccl.Items[i].Spec.Subjects),
+				Creator:             ccl.Items[i].GetAnnotations()[CreatorAnnotation],
+				Description:         ccl.Items[i].GetAnnotations()[DescriptionAnnotation],
+				Ticket:              ccl.Items[i].GetAnnotations()[TicketAnnotation],
 			},
 		}
+		if lease := GetLease(&ccl.Items[i]); lease.IsActive(time.Now()) {
+			pcc.Spec.LeaseHolder = lease.Holder
+			pcc.Spec.LeaseExpiry = lease.Expiry.Local().Format(time.RFC3339)
+			pcc.Spec.LeaseNote = lease.Note
+		}
 		clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
 		if err != nil {
 			pcc.Spec.ErrorMessage = err.Error()
@@ -498,6 +645,10 @@ func (cph *ClusterPoolHost) ConvertToPrintClusterClaimList(ccl *hivev1.ClusterCl
 			if ccl.Items[i].Spec.Lifetime != nil {
 				pcc.Spec.Lifetime = ccl.Items[i].Spec.Lifetime.Duration.String()
 			}
+			if f := clusterdeployment.GetClusterDeploymentFailure(dynamicClient, cd); f != nil {
+				pcc.Spec.FailureCategory = string(f.Category)
+				pcc.Spec.FailureHint = f.Hint
+			}
 		}
 		c := getClusterClaimPendingStatus(pcc.Spec.ClusterClaim)
 		if c != nil && c.Status == corev1.ConditionStatus(metav1.ConditionTrue) {
@@ -637,3 +788,48 @@ func (cph *ClusterPoolHost) OpenClusterClaim(clusterName string, timeout int, pr
 	}
 	return helpers.Openbrowser(cd.Status.WebConsoleURL)
 }
+
+// getClusterClaimPhase returns the phase of a clusterclaim and the last condition reason of its clusterdeployment
+func getClusterClaimPhase(dynamicClient dynamic.Interface, cc *hivev1.ClusterClaim) (phase, reason string) {
+	if len(cc.Spec.Namespace) == 0 {
+		if c := getClusterClaimPendingStatus(cc); c != nil {
+			return string(hivev1.ClusterClaimPendingCondition), c.Reason
+		}
+		return string(hivev1.ClusterClaimPendingCondition), ""
+	}
+	cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(cc.Spec.Namespace).Get(context.TODO(), cc.Spec.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return "Assigned", ""
+	}
+	cd := &hivev1.ClusterDeployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cdu.UnstructuredContent(), cd); err != nil {
+		return "Assigned", ""
+	}
+	var last *hivev1.ClusterDeploymentCondition
+	for i, c := range cd.Status.Conditions {
+		if c.Status == corev1.ConditionUnknown {
+			continue
+		}
+		if last == nil || last.LastTransitionTime.Before(&cd.Status.Conditions[i].LastTransitionTime) {
+			last = &cd.Status.Conditions[i]
+		}
+	}
+	if last != nil {
+		reason = fmt.Sprintf("%s %s", last.Type, last.Reason)
+	}
+	switch {
+	case !cd.Spec.Installed:
+		phase = "Installing"
+	case cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating:
+		phase = string(hivev1.ClusterPowerStateHibernating)
+	case len(cd.Status.PowerState) != 0:
+		phase = string(cd.Status.PowerState)
+	default:
+		phase = "Resuming"
+	}
+	return phase, reason
+}
+
+func trimClusterClaimErrorPrefix(msg string) string {
+	return clusterClaimErrorPrefixRegexp.ReplaceAllString(msg, "")
+}
@@ -0,0 +1,221 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// LeaseHolderAnnotation is the user who checked out the clusterclaim
+	LeaseHolderAnnotation = "cm-cli.open-cluster-management.io/lease-holder"
+	// LeaseExpiryAnnotation is the RFC3339 time at which the lease expires
+	LeaseExpiryAnnotation = "cm-cli.open-cluster-management.io/lease-expiry"
+	// LeaseNoteAnnotation is a free text explaining why the clusterclaim is checked out
+	LeaseNoteAnnotation = "cm-cli.open-cluster-management.io/lease-note"
+)
+
+// Lease is the checkout of a clusterclaim by a user
+type Lease struct {
+	Holder string
+	Expiry time.Time
+	Note   string
+}
+
+// GetLease returns the lease recorded on the clusterclaim, nil if none
+func GetLease(cc *hivev1.ClusterClaim) *Lease {
+	annotations := cc.GetAnnotations()
+	holder := annotations[LeaseHolderAnnotation]
+	if len(holder) == 0 {
+		return nil
+	}
+	l := &Lease{
+		Holder: holder,
+		Note:   annotations[LeaseNoteAnnotation],
+	}
+	// An unparsable expiry is considered as expired
+	if expiry, err := time.Parse(time.RFC3339, annotations[LeaseExpiryAnnotation]); err == nil {
+		l.Expiry = expiry
+	}
+	return l
+}
+
+// IsActive returns true if the lease is not expired at the given time
+func (l *Lease) IsActive(now time.Time) bool {
+	return l != nil && now.Before(l.Expiry)
+}
+
+func (l *Lease) String() string {
+	s := fmt.Sprintf("%s until %s", l.Holder, l.Expiry.Local().Format(time.RFC3339))
+	if len(l.Note) != 0 {
+		s = fmt.Sprintf("%s (%s)", s, l.Note)
+	}
+	return s
+}
+
+// CheckoutClusterClaims records a lease for the current user on the clusterclaims
+func (cph *ClusterPoolHost) CheckoutClusterClaims(clusterClaimNames string, duration time.Duration, note string, force, dryRun bool) error {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+
+	holder, err := cph.getUserName(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+
+	lease := &Lease{
+		Holder: holder,
+		Expiry: time.Now().Add(duration).UTC().Truncate(time.Second),
+		Note:   note,
+	}
+	return cph.updateClusterClaimsLease(dynamicClient, clusterClaimNames, holder, lease, force, dryRun)
+}
+
+// CheckinClusterClaims releases the lease on the clusterclaims
+func (cph *ClusterPoolHost) CheckinClusterClaims(clusterClaimNames string, force, dryRun bool) error {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+
+	holder, err := cph.getUserName(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+
+	return cph.updateClusterClaimsLease(dynamicClient, clusterClaimNames, holder, nil, force, dryRun)
+}
+
+// updateClusterClaimsLease sets the lease on the clusterclaims or removes it if the lease is nil
+func (cph *ClusterPoolHost) updateClusterClaimsLease(dynamicClient dynamic.Interface,
+	clusterClaimNames, holder string,
+	lease *Lease,
+	force, dryRun bool) error {
+	for _, ccn := range strings.Split(clusterClaimNames, ",") {
+		ccn := strings.TrimSpace(ccn)
+		ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Get(context.TODO(), ccn, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cc := &hivev1.ClusterClaim{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), cc); err != nil {
+			return err
+		}
+		if err := checkLease(cc, holder, force); err != nil {
+			return err
+		}
+		setLease(ccu, lease)
+		if lease != nil {
+			fmt.Printf("clusterclaim %s checked out by %s\n", ccn, lease)
+		} else {
+			fmt.Printf("clusterclaim %s checked in\n", ccn)
+		}
+		if dryRun {
+			continue
+		}
+		_, err = dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Update(context.TODO(), ccu, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkClusterClaimsLease returns an error if one of the clusterclaims is checked out
+// by another user, only a warning is displayed if force is set.
+func (cph *ClusterPoolHost) checkClusterClaimsLease(dynamicClient dynamic.Interface,
+	clusterPoolRestConfig *rest.Config,
+	clusterClaimNames string,
+	force bool) error {
+	var holder string
+	for _, ccn := range strings.Split(clusterClaimNames, ",") {
+		ccn := strings.TrimSpace(ccn)
+		ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Get(context.TODO(), ccn, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cc := &hivev1.ClusterClaim{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), cc); err != nil {
+			return err
+		}
+		if !GetLease(cc).IsActive(time.Now()) {
+			continue
+		}
+		if len(holder) == 0 {
+			holder, err = cph.getUserName(clusterPoolRestConfig)
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkLease(cc, holder, force); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLease returns an error if the clusterclaim has an active lease held by another user,
+// if force is set a warning is displayed instead.
+func checkLease(cc *hivev1.ClusterClaim, holder string, force bool) error {
+	lease := GetLease(cc)
+	if !lease.IsActive(time.Now()) || lease.Holder == holder {
+		return nil
+	}
+	if force {
+		fmt.Printf("Warning: clusterclaim %s is checked out by %s\n", cc.Name, lease)
+		return nil
+	}
+	return fmt.Errorf("clusterclaim %s is checked out by %s, use --force to override", cc.Name, lease)
+}
+
+func setLease(ccu *unstructured.Unstructured, lease *Lease) {
+	annotations := ccu.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if lease == nil {
+		delete(annotations, LeaseHolderAnnotation)
+		delete(annotations, LeaseExpiryAnnotation)
+		delete(annotations, LeaseNoteAnnotation)
+	} else {
+		annotations[LeaseHolderAnnotation] = lease.Holder
+		annotations[LeaseExpiryAnnotation] = lease.Expiry.UTC().Format(time.RFC3339)
+		if len(lease.Note) != 0 {
+			annotations[LeaseNoteAnnotation] = lease.Note
+		} else {
+			delete(annotations, LeaseNoteAnnotation)
+		}
+	}
+	ccu.SetAnnotations(annotations)
+}
+
+// getUserName returns the name of the current user on the clusterpoolhost
+func (cph *ClusterPoolHost) getUserName(clusterPoolRestConfig *rest.Config) (string, error) {
+	me, err := WhoAmI(clusterPoolRestConfig)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(me.Name, "system:serviceaccount:"+cph.Namespace+":"), nil
+}
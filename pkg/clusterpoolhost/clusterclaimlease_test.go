@@ -0,0 +1,108 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newLeasedClusterClaim(name, holder string, expiry time.Time) *hivev1.ClusterClaim {
+	cc := &hivev1.ClusterClaim{
+		TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterClaim"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "mynamespace",
+		},
+	}
+	if len(holder) != 0 {
+		cc.Annotations = map[string]string{
+			LeaseHolderAnnotation: holder,
+			LeaseExpiryAnnotation: expiry.UTC().Format(time.RFC3339),
+			LeaseNoteAnnotation:   "perf test",
+		}
+	}
+	return cc
+}
+
+func TestCheckLease(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		cc      *hivev1.ClusterClaim
+		force   bool
+		wantErr bool
+	}{
+		{
+			name: "no lease",
+			cc:   newLeasedClusterClaim("mycc", "", now),
+		},
+		{
+			name: "held by me",
+			cc:   newLeasedClusterClaim("mycc", "me", now.Add(time.Hour)),
+		},
+		{
+			name: "expired lease held by other",
+			cc:   newLeasedClusterClaim("mycc", "other", now.Add(-time.Hour)),
+		},
+		{
+			name:    "active lease held by other",
+			cc:      newLeasedClusterClaim("mycc", "other", now.Add(time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:  "active lease held by other with force",
+			cc:    newLeasedClusterClaim("mycc", "other", now.Add(time.Hour)),
+			force: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkLease(tt.cc, "me", tt.force); (err != nil) != tt.wantErr {
+				t.Errorf("checkLease() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateClusterClaimsLease(t *testing.T) {
+	now := time.Now()
+	dynamicClient := newFakeDynamicClient(t,
+		newLeasedClusterClaim("free", "", now),
+		newLeasedClusterClaim("taken", "other", now.Add(time.Hour)))
+	cph := &ClusterPoolHost{Name: "mycph", Namespace: "mynamespace"}
+
+	lease := &Lease{Holder: "me", Expiry: now.Add(4 * time.Hour).UTC().Truncate(time.Second), Note: "perf test"}
+	if err := cph.updateClusterClaimsLease(dynamicClient, "free", "me", lease, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := cph.updateClusterClaimsLease(dynamicClient, "free,taken", "me", lease, false, false); err == nil {
+		t.Error("expected an error when checking out a clusterclaim held by another user")
+	}
+
+	ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace("mynamespace").Get(context.TODO(), "free", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc := &hivev1.ClusterClaim{ObjectMeta: metav1.ObjectMeta{Name: "free", Annotations: ccu.GetAnnotations()}}
+	got := GetLease(cc)
+	if got == nil || got.Holder != "me" || !got.Expiry.Equal(lease.Expiry) || got.Note != "perf test" {
+		t.Errorf("got lease %v, want %v", got, lease)
+	}
+
+	if err := cph.updateClusterClaimsLease(dynamicClient, "free", "me", nil, false, false); err != nil {
+		t.Fatal(err)
+	}
+	ccu, err = dynamicClient.Resource(helpers.GvrCC).Namespace("mynamespace").Get(context.TODO(), "free", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc.Annotations = ccu.GetAnnotations()
+	if GetLease(cc) != nil {
+		t.Errorf("expected the lease to be released, got %v", GetLease(cc))
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// CreatorAnnotation is the user who created the clusterclaim with cm
+	CreatorAnnotation = "cm-cli.open-cluster-management.io/creator"
+	// CreatorVersionAnnotation is the version of cm used to create the clusterclaim
+	CreatorVersionAnnotation = "cm-cli.open-cluster-management.io/creator-version"
+	// DescriptionAnnotation describes the purpose of the clusterclaim
+	DescriptionAnnotation = "cm-cli.open-cluster-management.io/description"
+	// TicketAnnotation is the ticket for which the clusterclaim was created
+	TicketAnnotation = "cm-cli.open-cluster-management.io/ticket"
+)
+
+// ClusterClaimMetadata is the purpose of the clusterclaims recorded at creation
+type ClusterClaimMetadata struct {
+	Description string
+	Ticket      string
+}
+
+// ClusterClaimFilter selects clusterclaims, all set filters must match
+type ClusterClaimFilter struct {
+	// Select the clusterclaims created by or accessible to the current user
+	Mine bool
+	// Select the clusterclaims created by or accessible to this user
+	Owner string
+	// Select the clusterclaims where the text is found in the name, clusterpool, description, ticket or lease note
+	Search string
+}
+
+// IsEmpty returns true if no filter is set
+func (f *ClusterClaimFilter) IsEmpty() bool {
+	return !f.Mine && len(f.Owner) == 0 && len(f.Search) == 0
+}
+
+// FilterClusterClaims returns the clusterclaims matching the filter
+func (cph *ClusterPoolHost) FilterClusterClaims(ccl *hivev1.ClusterClaimList, f *ClusterClaimFilter) (*hivev1.ClusterClaimList, error) {
+	if f.IsEmpty() {
+		return ccl, nil
+	}
+	owner := f.Owner
+	if f.Mine {
+		clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+		if err != nil {
+			return nil, err
+		}
+		owner, err = cph.getUserName(clusterPoolRestConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filtered := &hivev1.ClusterClaimList{}
+	for i := range ccl.Items {
+		if matchClusterClaim(&ccl.Items[i], owner, f.Search) {
+			filtered.Items = append(filtered.Items, ccl.Items[i])
+		}
+	}
+	return filtered, nil
+}
+
+func matchClusterClaim(cc *hivev1.ClusterClaim, owner, search string) bool {
+	annotations := cc.GetAnnotations()
+	if len(owner) != 0 &&
+		annotations[CreatorAnnotation] != owner &&
+		!hasOwner(cc.Spec.Subjects, owner) {
+		return false
+	}
+	if len(search) != 0 {
+		search = strings.ToLower(search)
+		for _, s := range []string{
+			cc.Name,
+			cc.Spec.ClusterPoolName,
+			annotations[DescriptionAnnotation],
+			annotations[TicketAnnotation],
+			annotations[LeaseNoteAnnotation],
+		} {
+			if strings.Contains(strings.ToLower(s), search) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
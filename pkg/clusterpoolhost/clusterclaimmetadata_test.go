@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchClusterClaim(t *testing.T) {
+	cc := &hivev1.ClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mycc",
+			Annotations: map[string]string{
+				CreatorAnnotation:     "alice",
+				DescriptionAnnotation: "Perf test of the search API",
+				TicketAnnotation:      "JIRA-1234",
+			},
+		},
+		Spec: hivev1.ClusterClaimSpec{
+			ClusterPoolName: "mypool",
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "alice"},
+				{Kind: rbacv1.UserKind, Name: "bob"},
+				{Kind: rbacv1.GroupKind, Name: "team"},
+			},
+		},
+	}
+	tests := []struct {
+		name   string
+		owner  string
+		search string
+		want   bool
+	}{
+		{name: "no filter", want: true},
+		{name: "creator", owner: "alice", want: true},
+		{name: "subject", owner: "bob", want: true},
+		{name: "group is not an owner", owner: "team", want: false},
+		{name: "other owner", owner: "carol", want: false},
+		{name: "search description case insensitive", search: "perf", want: true},
+		{name: "search ticket", search: "jira-1234", want: true},
+		{name: "search pool", search: "mypool", want: true},
+		{name: "search not found", search: "upgrade", want: false},
+		{name: "owner and search", owner: "bob", search: "JIRA", want: true},
+		{name: "owner matches search does not", owner: "bob", search: "upgrade", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchClusterClaim(cc, tt.owner, tt.search); got != tt.want {
+				t.Errorf("matchClusterClaim() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+)
+
+// ClusterClaimSubjectsChange describes the changes to apply on the subjects of a clusterclaim
+type ClusterClaimSubjectsChange struct {
+	// Users to add
+	AddUsers []string
+	// Groups to add
+	AddGroups []string
+	// Subjects to remove, formatted as [<kind>:]<name>
+	RemoveSubjects []string
+	// If set, the user which replaces all User and ServiceAccount subjects
+	Owner string
+}
+
+// IsEmpty returns true if no change is requested
+func (c *ClusterClaimSubjectsChange) IsEmpty() bool {
+	return len(c.AddUsers) == 0 &&
+		len(c.AddGroups) == 0 &&
+		len(c.RemoveSubjects) == 0 &&
+		len(c.Owner) == 0
+}
+
+// SetClusterClaimsSubjects updates the subjects which have access to the claimed clusters
+func (cph *ClusterPoolHost) SetClusterClaimsSubjects(clusterClaimNames string, change *ClusterClaimSubjectsChange, dryRun bool) error {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, ccn := range strings.Split(clusterClaimNames, ",") {
+		ccn := strings.TrimSpace(ccn)
+		ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Get(context.TODO(), ccn, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cc := &hivev1.ClusterClaim{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), cc); err != nil {
+			return err
+		}
+		subjects, err := change.apply(cc.Spec.Subjects)
+		if err != nil {
+			return fmt.Errorf("clusterclaim %s: %v", ccn, err)
+		}
+		cc.Spec.Subjects = subjects
+		fmt.Printf("clusterclaim %s subjects: %s\n", ccn, FormatSubjects(subjects))
+		if dryRun {
+			continue
+		}
+		ccu.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(cc)
+		if err != nil {
+			return err
+		}
+		_, err = dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Update(context.TODO(), ccu, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ClusterClaimSubjectsChange) apply(subjects []rbacv1.Subject) ([]rbacv1.Subject, error) {
+	newSubjects := make([]rbacv1.Subject, 0)
+	for _, s := range subjects {
+		if len(c.Owner) != 0 && (s.Kind == rbacv1.UserKind || s.Kind == rbacv1.ServiceAccountKind) {
+			continue
+		}
+		newSubjects = append(newSubjects, s)
+	}
+	if len(c.Owner) != 0 {
+		newSubjects = addSubject(newSubjects, newUserSubject(c.Owner))
+	}
+	for _, u := range c.AddUsers {
+		newSubjects = addSubject(newSubjects, newUserSubject(u))
+	}
+	for _, g := range c.AddGroups {
+		newSubjects = addSubject(newSubjects, rbacv1.Subject{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.GroupKind,
+			Name:     g,
+		})
+	}
+	for _, r := range c.RemoveSubjects {
+		kind, name := parseSubject(r)
+		found := false
+		for i := len(newSubjects) - 1; i >= 0; i-- {
+			s := newSubjects[i]
+			if s.Name == name && (len(kind) == 0 || strings.EqualFold(s.Kind, kind)) {
+				newSubjects = append(newSubjects[:i], newSubjects[i+1:]...)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("subject %s not found", r)
+		}
+	}
+	if len(newSubjects) == 0 {
+		return nil, fmt.Errorf("the clusterclaim must keep at least one subject")
+	}
+	return newSubjects, nil
+}
+
+func newUserSubject(name string) rbacv1.Subject {
+	return rbacv1.Subject{
+		APIGroup: rbacv1.GroupName,
+		Kind:     rbacv1.UserKind,
+		Name:     name,
+	}
+}
+
+func addSubject(subjects []rbacv1.Subject, subject rbacv1.Subject) []rbacv1.Subject {
+	for _, s := range subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+			return subjects
+		}
+	}
+	return append(subjects, subject)
+}
+
+// parseSubject splits a [<kind>:]<name> subject
+func parseSubject(s string) (kind, name string) {
+	ss := strings.SplitN(s, ":", 2)
+	if len(ss) == 2 {
+		switch strings.ToLower(ss[0]) {
+		case "user", "group", "serviceaccount":
+			return ss[0], ss[1]
+		}
+	}
+	return "", s
+}
+
+// FormatSubjects returns a comma-separated list of <kind>:<name>
+func FormatSubjects(subjects []rbacv1.Subject) string {
+	ss := make([]string, len(subjects))
+	for i, s := range subjects {
+		ss[i] = fmt.Sprintf("%s:%s", s.Kind, s.Name)
+	}
+	return strings.Join(ss, ",")
+}
@@ -0,0 +1,73 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestClusterClaimSubjectsChange_apply(t *testing.T) {
+	subjects := []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Name: "creator", Namespace: "mynamespace"},
+		{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "myteam"},
+		{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:mynamespace"},
+	}
+	tests := []struct {
+		name    string
+		change  ClusterClaimSubjectsChange
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "add user and group",
+			change: ClusterClaimSubjectsChange{AddUsers: []string{"alice"}, AddGroups: []string{"qe", "myteam"}},
+			want:   "ServiceAccount:creator,Group:myteam,Group:system:serviceaccounts:mynamespace,User:alice,Group:qe",
+		},
+		{
+			name:   "remove subject with kind",
+			change: ClusterClaimSubjectsChange{RemoveSubjects: []string{"Group:myteam"}},
+			want:   "ServiceAccount:creator,Group:system:serviceaccounts:mynamespace",
+		},
+		{
+			name:   "remove subject without kind",
+			change: ClusterClaimSubjectsChange{RemoveSubjects: []string{"system:serviceaccounts:mynamespace"}},
+			want:   "ServiceAccount:creator,Group:myteam",
+		},
+		{
+			name:    "remove unknown subject",
+			change:  ClusterClaimSubjectsChange{RemoveSubjects: []string{"User:bob"}},
+			wantErr: true,
+		},
+		{
+			name:   "transfer ownership",
+			change: ClusterClaimSubjectsChange{Owner: "bob"},
+			want:   "Group:myteam,Group:system:serviceaccounts:mynamespace,User:bob",
+		},
+		{
+			name: "remove all subjects",
+			change: ClusterClaimSubjectsChange{RemoveSubjects: []string{
+				"creator",
+				"myteam",
+				"system:serviceaccounts:mynamespace",
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make([]rbacv1.Subject, len(subjects))
+			copy(in, subjects)
+			got, err := tt.change.apply(in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if FormatSubjects(got) != tt.want {
+				t.Errorf("apply() = %s, want %s", FormatSubjects(got), tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stolostron/applier/pkg/apply"
 	printclusterpoolv1alpha1 "github.com/stolostron/cm-cli/api/cm-cli/v1alpha1"
 	"github.com/stolostron/cm-cli/pkg/clusterpoolhost/scenario"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	"github.com/stolostron/cm-cli/pkg/helpers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -54,7 +55,7 @@ func (cph *ClusterPoolHost) SizeClusterPool(clusterPoolName string, size int32,
 	return nil
 }
 
-func (cph *ClusterPoolHost) CreateClusterPool(clusterPoolName, cloud string, values map[string]interface{}, dryRun bool, outputFile string) error {
+func (cph *ClusterPoolHost) CreateClusterPool(clusterPoolName, cloud string, values map[string]interface{}, cmFlags *genericclioptionscm.CMFlags, outputFile string, exportFlags *helpers.ExportFlags) error {
 	values["namespace"] = cph.Namespace
 
 	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
@@ -86,7 +87,7 @@ func (cph *ClusterPoolHost) CreateClusterPool(clusterPoolName, cloud string, val
 		"create/clusterpool/common/namespace.yaml",
 	}
 
-	out, err := applier.ApplyDirectly(reader, values, dryRun, "", files...)
+	out, err := applier.ApplyDirectly(reader, values, cmFlags.DryRun, "", files...)
 	if err != nil {
 		return err
 	}
@@ -108,13 +109,16 @@ func (cph *ClusterPoolHost) CreateClusterPool(clusterPoolName, cloud string, val
 		files = append(files, "create/clusterpool/common/clusterimageset_cr.yaml",
 			"create/clusterpool/common/clusterpool_cr.yaml")
 	}
-	out, err = applier.ApplyCustomResources(reader, values, dryRun, "create/clusterpool/common/_helpers.tpl", files...)
+	out, err = applier.ApplyCustomResources(reader, values, cmFlags.DryRun, "create/clusterpool/common/_helpers.tpl", files...)
 	if err != nil {
 		return err
 	}
 	output = append(output, out...)
 
-	return apply.WriteOutput(outputFile, output)
+	if err := exportFlags.Export(output); err != nil {
+		return err
+	}
+	return helpers.WriteOutput(cmFlags, applierBuilder, outputFile, output)
 }
 
 func (cph *ClusterPoolHost) DeleteClusterPools(clusterPoolNames string, dryRun bool, outputFile string) error {
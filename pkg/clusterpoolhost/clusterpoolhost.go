@@ -45,6 +45,8 @@ type ClusterPoolHost struct {
 	Group string `json:"group"`
 	//ServerNamespace namespace where RHACM or MCE is installed
 	ServerNamespace string `json:"serverNamespace"`
+	// Hooks run around the hibernation and the resume of the clusterclaims
+	Hooks *helpers.Hooks `json:"hooks,omitempty"`
 }
 
 type ErrorType string
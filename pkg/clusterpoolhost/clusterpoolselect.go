@@ -0,0 +1,73 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectClusterPool returns the first clusterpool having enough ready or standby clusters
+// for the number of claims. The candidates are the clusterPoolNames in order or, if empty,
+// the clusterpools matching the labelSelector sorted by name.
+func (cph *ClusterPoolHost) SelectClusterPool(clusterPoolNames []string, labelSelector string, claims int, dryRun bool) (string, error) {
+	cpl, err := cph.GetClusterPools(false, dryRun)
+	if err != nil {
+		return "", err
+	}
+	selector := labels.Everything()
+	if len(labelSelector) != 0 {
+		selector, err = labels.Parse(labelSelector)
+		if err != nil {
+			return "", err
+		}
+	}
+	cp, err := selectClusterPool(cpl, clusterPoolNames, selector, claims)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("clusterpool %s selected (ready: %d, standby: %d)\n", cp.Name, cp.Status.Ready, cp.Status.Standby)
+	return cp.Name, nil
+}
+
+func selectClusterPool(cpl *hivev1.ClusterPoolList, clusterPoolNames []string, selector labels.Selector, claims int) (*hivev1.ClusterPool, error) {
+	candidates := make([]*hivev1.ClusterPool, 0)
+	if len(clusterPoolNames) != 0 {
+		for _, name := range clusterPoolNames {
+			found := false
+			for i := range cpl.Items {
+				if cpl.Items[i].Name == name {
+					candidates = append(candidates, &cpl.Items[i])
+					found = true
+					break
+				}
+			}
+			if !found {
+				fmt.Printf("clusterpool %s not found, skipping\n", name)
+			}
+		}
+	} else {
+		for i := range cpl.Items {
+			if selector.Matches(labels.Set(cpl.Items[i].Labels)) {
+				candidates = append(candidates, &cpl.Items[i])
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Name < candidates[j].Name
+		})
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no candidate clusterpool found")
+	}
+	status := make([]string, 0)
+	for _, cp := range candidates {
+		if int(cp.Status.Ready+cp.Status.Standby) >= claims {
+			return cp, nil
+		}
+		status = append(status, fmt.Sprintf("%s (ready: %d, standby: %d)", cp.Name, cp.Status.Ready, cp.Status.Standby))
+	}
+	return nil, fmt.Errorf("no clusterpool has %d ready or standby clusters: %s", claims, strings.Join(status, ", "))
+}
@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestSelectClusterPool(t *testing.T) {
+	newCP := func(name, cloud string, ready, standby int32) hivev1.ClusterPool {
+		return hivev1.ClusterPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"cloud": cloud},
+			},
+			Status: hivev1.ClusterPoolStatus{
+				Ready:   ready,
+				Standby: standby,
+			},
+		}
+	}
+	cpl := &hivev1.ClusterPoolList{
+		Items: []hivev1.ClusterPool{
+			newCP("poola", "aws", 0, 0),
+			newCP("poolb", "aws", 0, 1),
+			newCP("poolc", "gcp", 2, 0),
+		},
+	}
+	tests := []struct {
+		name     string
+		names    []string
+		selector string
+		claims   int
+		want     string
+		wantErr  bool
+	}{
+		{name: "fall back in order", names: []string{"poola", "poolb", "poolc"}, claims: 1, want: "poolb"},
+		{name: "order is kept", names: []string{"poolc", "poolb"}, claims: 1, want: "poolc"},
+		{name: "not enough for all claims", names: []string{"poolb", "poolc"}, claims: 2, want: "poolc"},
+		{name: "missing pool skipped", names: []string{"missing", "poolb"}, claims: 1, want: "poolb"},
+		{name: "no capacity", names: []string{"poola"}, claims: 1, wantErr: true},
+		{name: "selector", selector: "cloud=aws", claims: 1, want: "poolb"},
+		{name: "selector no capacity", selector: "cloud=aws", claims: 2, wantErr: true},
+		{name: "selector no match", selector: "cloud=azure", claims: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := selectClusterPool(cpl, tt.names, selector, tt.claims)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectClusterPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name != tt.want {
+				t.Errorf("selectClusterPool() = %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+}
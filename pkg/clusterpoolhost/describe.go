@@ -0,0 +1,269 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/describe"
+)
+
+// ClusterClaimDescription gathers all objects related to a clusterclaim
+type ClusterClaimDescription struct {
+	ClusterPoolHostName string
+	ClusterClaim        *hivev1.ClusterClaim
+	ClusterDeployment   *hivev1.ClusterDeployment
+	ClusterProvisions   []hivev1.ClusterProvision
+	Events              *corev1.EventList
+	// Errors collected while gathering the related objects
+	Errors []error
+}
+
+// DescribeClusterClaim writes a human-readable report of a clusterclaim and its provisioning
+func (cph *ClusterPoolHost) DescribeClusterClaim(clusterClaimName string, showEvents bool, out io.Writer) error {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+	d, err := getClusterClaimDescription(dynamicClient, kubeClient, cph.Namespace, clusterClaimName, showEvents)
+	if err != nil {
+		return err
+	}
+	d.ClusterPoolHostName = cph.Name
+	return d.Print(out)
+}
+
+func getClusterClaimDescription(dynamicClient dynamic.Interface,
+	kubeClient kubernetes.Interface,
+	namespace, clusterClaimName string,
+	showEvents bool) (*ClusterClaimDescription, error) {
+	ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(namespace).Get(context.TODO(), clusterClaimName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	d := &ClusterClaimDescription{
+		ClusterClaim: &hivev1.ClusterClaim{},
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), d.ClusterClaim); err != nil {
+		return nil, err
+	}
+
+	if showEvents {
+		d.Events = &corev1.EventList{}
+		el, err := kubeClient.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{
+			FieldSelector: fields.Set{
+				"involvedObject.kind": "ClusterClaim",
+				"involvedObject.name": clusterClaimName,
+			}.AsSelector().String(),
+		})
+		if err != nil {
+			d.Errors = append(d.Errors, fmt.Errorf("unable to list events for clusterclaim %s: %v", clusterClaimName, err))
+		} else {
+			d.Events.Items = append(d.Events.Items, el.Items...)
+		}
+	}
+
+	cdNamespace := d.ClusterClaim.Spec.Namespace
+	if len(cdNamespace) == 0 {
+		return d, nil
+	}
+
+	cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(cdNamespace).Get(context.TODO(), cdNamespace, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		d.Errors = append(d.Errors, fmt.Errorf("clusterdeployment %s not found", cdNamespace))
+	case err != nil:
+		d.Errors = append(d.Errors, fmt.Errorf("unable to get clusterdeployment %s: %v", cdNamespace, err))
+	default:
+		cd := &hivev1.ClusterDeployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cdu.UnstructuredContent(), cd); err != nil {
+			return nil, err
+		}
+		d.ClusterDeployment = cd
+	}
+
+	cprs, err := clusterdeployment.GetClusterProvisions(dynamicClient, cdNamespace)
+	if err != nil {
+		d.Errors = append(d.Errors, fmt.Errorf("unable to list clusterprovisions in %s: %v", cdNamespace, err))
+	} else {
+		d.ClusterProvisions = cprs
+	}
+
+	if showEvents {
+		el, err := kubeClient.CoreV1().Events(cdNamespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			d.Errors = append(d.Errors, fmt.Errorf("unable to list events in %s: %v", cdNamespace, err))
+		} else {
+			d.Events.Items = append(d.Events.Items, el.Items...)
+		}
+	}
+	return d, nil
+}
+
+// Print writes the description in a kubectl describe like format
+func (d *ClusterClaimDescription) Print(out io.Writer) error {
+	tw := printers.GetNewTabWriter(out)
+	w := describe.NewPrefixWriter(tw)
+	cc := d.ClusterClaim
+	w.Write(describe.LEVEL_0, "Name:\t%s\n", cc.Name)
+	w.Write(describe.LEVEL_0, "Namespace:\t%s\n", cc.Namespace)
+	if len(d.ClusterPoolHostName) != 0 {
+		w.Write(describe.LEVEL_0, "ClusterPoolHost:\t%s\n", d.ClusterPoolHostName)
+	}
+	w.Write(describe.LEVEL_0, "ClusterPool:\t%s\n", cc.Spec.ClusterPoolName)
+	w.Write(describe.LEVEL_0, "Age:\t%s\n", helpers.TimeDiff(cc.CreationTimestamp.Time, time.Second))
+	lifetime := "<none>"
+	if cc.Spec.Lifetime != nil {
+		lifetime = cc.Spec.Lifetime.Duration.String()
+	}
+	w.Write(describe.LEVEL_0, "Lifetime:\t%s\n", lifetime)
+	w.Write(describe.LEVEL_0, "Cluster ID:\t%s\n", valueOrNone(cc.Spec.Namespace))
+
+	if len(cc.Spec.Subjects) == 0 {
+		w.Write(describe.LEVEL_0, "Subjects:\t<none>\n")
+	} else {
+		w.Write(describe.LEVEL_0, "Subjects:\n")
+		w.Write(describe.LEVEL_1, "Kind\tName\tNamespace\n")
+		w.Write(describe.LEVEL_1, "----\t----\t---------\n")
+		for _, s := range cc.Spec.Subjects {
+			w.Write(describe.LEVEL_1, "%s\t%s\t%s\n", s.Kind, s.Name, s.Namespace)
+		}
+	}
+
+	if len(cc.Status.Conditions) == 0 {
+		w.Write(describe.LEVEL_0, "Claim Conditions:\t<none>\n")
+	} else {
+		w.Write(describe.LEVEL_0, "Claim Conditions:\n")
+		w.Write(describe.LEVEL_1, "Type\tStatus\tLastTransitionTime\tReason\tMessage\n")
+		w.Write(describe.LEVEL_1, "----\t------\t------------------\t------\t-------\n")
+		for _, c := range cc.Status.Conditions {
+			w.Write(describe.LEVEL_1, "%s\t%s\t%s\t%s\t%s\n",
+				c.Type,
+				c.Status,
+				c.LastTransitionTime.Time.Format(time.RFC1123Z),
+				c.Reason,
+				c.Message)
+		}
+	}
+
+	if cd := d.ClusterDeployment; cd != nil {
+		w.Write(describe.LEVEL_0, "Cluster Deployment:\n")
+		w.Write(describe.LEVEL_1, "Name:\t%s\n", cd.Name)
+		w.Write(describe.LEVEL_1, "Power State:\t%s\n", valueOrNone(string(cd.Spec.PowerState)))
+		w.Write(describe.LEVEL_1, "Observed Power State:\t%s\n", valueOrNone(string(cd.Status.PowerState)))
+		w.Write(describe.LEVEL_1, "Hibernate Label:\t%s\n", valueOrNone(cd.Labels["hibernate"]))
+		w.Write(describe.LEVEL_1, "Installed:\t%t\n", cd.Spec.Installed)
+		w.Write(describe.LEVEL_1, "Base Domain:\t%s\n", valueOrNone(cd.Spec.BaseDomain))
+		w.Write(describe.LEVEL_1, "API URL:\t%s\n", valueOrNone(cd.Status.APIURL))
+		w.Write(describe.LEVEL_1, "Console URL:\t%s\n", valueOrNone(cd.Status.WebConsoleURL))
+		imageSet := ""
+		if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ImageSetRef != nil {
+			imageSet = cd.Spec.Provisioning.ImageSetRef.Name
+		}
+		w.Write(describe.LEVEL_1, "Image Set:\t%s\n", valueOrNone(imageSet))
+		installVersion := ""
+		if cd.Status.InstallVersion != nil {
+			installVersion = *cd.Status.InstallVersion
+		}
+		w.Write(describe.LEVEL_1, "Install Version:\t%s\n", valueOrNone(installVersion))
+		w.Write(describe.LEVEL_1, "Install Restarts:\t%d\n", cd.Status.InstallRestarts)
+		conditions := make([]hivev1.ClusterDeploymentCondition, 0)
+		for _, c := range cd.Status.Conditions {
+			if c.Status != corev1.ConditionUnknown {
+				conditions = append(conditions, c)
+			}
+		}
+		if len(conditions) == 0 {
+			w.Write(describe.LEVEL_1, "Conditions:\t<none>\n")
+		} else {
+			w.Write(describe.LEVEL_1, "Conditions:\n")
+			w.Write(describe.LEVEL_2, "Type\tStatus\tLastTransitionTime\tReason\tMessage\n")
+			w.Write(describe.LEVEL_2, "----\t------\t------------------\t------\t-------\n")
+			for _, c := range conditions {
+				w.Write(describe.LEVEL_2, "%s\t%s\t%s\t%s\t%s\n",
+					c.Type,
+					c.Status,
+					c.LastTransitionTime.Time.Format(time.RFC1123Z),
+					c.Reason,
+					strings.TrimSpace(c.Message))
+			}
+		}
+	}
+
+	if d.ClusterDeployment != nil {
+		var cpr *hivev1.ClusterProvision
+		if len(d.ClusterProvisions) != 0 && !d.ClusterDeployment.Spec.Installed {
+			cpr = &d.ClusterProvisions[len(d.ClusterProvisions)-1]
+		}
+		if f := clusterdeployment.ClassifyFailure(d.ClusterDeployment, cpr); f != nil {
+			w.Write(describe.LEVEL_0, "Failure:\n")
+			w.Write(describe.LEVEL_1, "Category:\t%s\n", f.Category)
+			w.Write(describe.LEVEL_1, "Provision Stopped:\t%t\n", f.Stopped)
+			w.Write(describe.LEVEL_1, "Message:\t%s\n", f.Message)
+			w.Write(describe.LEVEL_1, "Hint:\t%s\n", f.Hint)
+		}
+	}
+
+	if len(d.ClusterProvisions) == 0 {
+		w.Write(describe.LEVEL_0, "Provision Attempts:\t<none>\n")
+	} else {
+		w.Write(describe.LEVEL_0, "Provision Attempts:\n")
+		w.Write(describe.LEVEL_1, "Attempt\tName\tStage\tAge\tMessage\n")
+		w.Write(describe.LEVEL_1, "-------\t----\t-----\t---\t-------\n")
+		for _, cpr := range d.ClusterProvisions {
+			w.Write(describe.LEVEL_1, "%d\t%s\t%s\t%s\t%s\n",
+				cpr.Spec.Attempt,
+				cpr.Name,
+				cpr.Spec.Stage,
+				helpers.TimeDiff(cpr.CreationTimestamp.Time, time.Minute),
+				getClusterProvisionFailedMessage(&cpr))
+		}
+	}
+
+	for _, err := range d.Errors {
+		w.Write(describe.LEVEL_0, "Warning:\t%s\n", err.Error())
+	}
+
+	if d.Events != nil {
+		describe.DescribeEvents(d.Events, w)
+	}
+	return tw.Flush()
+}
+
+func getClusterProvisionFailedMessage(cpr *hivev1.ClusterProvision) string {
+	for _, c := range cpr.Status.Conditions {
+		if c.Type == hivev1.ClusterProvisionFailedCondition && c.Status == corev1.ConditionTrue {
+			return strings.TrimSpace(c.Message)
+		}
+	}
+	return ""
+}
+
+func valueOrNone(s string) string {
+	if len(s) == 0 {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &unstructured.Unstructured{Object: u}
+}
+
+func newFakeDynamicClient(t *testing.T, objs ...runtime.Object) *fakedynamic.FakeDynamicClient {
+	uobjs := make([]runtime.Object, 0)
+	for _, obj := range objs {
+		uobjs = append(uobjs, toUnstructured(t, obj))
+	}
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			helpers.GvrCC:  "ClusterClaimList",
+			helpers.GvrCD:  "ClusterDeploymentList",
+			helpers.GvrCP:  "ClusterPoolList",
+			helpers.GvrCPR: "ClusterProvisionList",
+		},
+		uobjs...)
+}
+
+func TestDescribeClusterClaim(t *testing.T) {
+	cc := &hivev1.ClusterClaim{
+		TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterClaim"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mycc",
+			Namespace: "mynamespace",
+		},
+		Spec: hivev1.ClusterClaimSpec{
+			ClusterPoolName: "mypool",
+			Namespace:       "mypool-abcde",
+			Subjects: []rbacv1.Subject{
+				{Kind: "Group", Name: "mygroup"},
+			},
+		},
+	}
+	cd := &hivev1.ClusterDeployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterDeployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mypool-abcde",
+			Namespace: "mypool-abcde",
+			Labels:    map[string]string{"hibernate": "skip"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			BaseDomain: "example.com",
+			PowerState: hivev1.ClusterPowerStateRunning,
+			Provisioning: &hivev1.Provisioning{
+				ImageSetRef: &hivev1.ClusterImageSetReference{Name: "img4.10.3"},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			APIURL:        "https://api.mypool-abcde.example.com:6443",
+			WebConsoleURL: "https://console.apps.mypool-abcde.example.com",
+		},
+	}
+	cpr := &hivev1.ClusterProvision{
+		TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterProvision"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mypool-abcde-0-xyz",
+			Namespace: "mypool-abcde",
+			Labels:    map[string]string{clusterdeployment.ClusterDeploymentNameLabel: "mypool-abcde"},
+		},
+		Spec: hivev1.ClusterProvisionSpec{
+			Attempt: 0,
+			Stage:   hivev1.ClusterProvisionStageFailed,
+		},
+		Status: hivev1.ClusterProvisionStatus{
+			Conditions: []hivev1.ClusterProvisionCondition{
+				{
+					Type:    hivev1.ClusterProvisionFailedCondition,
+					Status:  corev1.ConditionTrue,
+					Message: "quota exceeded",
+				},
+			},
+		},
+	}
+	dynamicClient := newFakeDynamicClient(t, cc, cd, cpr)
+	kubeClient := fakekubernetes.NewSimpleClientset()
+
+	d, err := getClusterClaimDescription(dynamicClient, kubeClient, "mynamespace", "mycc", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ClusterDeployment == nil {
+		t.Fatal("expected the clusterdeployment to be found")
+	}
+	if len(d.ClusterProvisions) != 1 {
+		t.Fatalf("expected 1 clusterprovision, got %d", len(d.ClusterProvisions))
+	}
+	var out bytes.Buffer
+	if err := d.Print(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"mycc",
+		"mypool",
+		"mygroup",
+		"skip",
+		"img4.10.3",
+		"https://api.mypool-abcde.example.com:6443",
+		"quota exceeded",
+		"QuotaExceeded",
+		"Events:",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+
+	if _, err := getClusterClaimDescription(dynamicClient, kubeClient, "mynamespace", "notfound", false); err == nil {
+		t.Error("expected an error for a missing clusterclaim")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+// RunClusterClaimsHooks runs the hooks of a phase for each clusterclaim.
+// Before running the post-hibernate and post-run hooks, it waits until the clusters reach
+// the power state for at most timeout minutes.
+// The hooks of a running cluster use the context created by SetClusterClaimContext.
+func (cph *ClusterPoolHost) RunClusterClaimsHooks(clusterClaimNames string,
+	hooks *helpers.Hooks,
+	phase helpers.HookPhase,
+	timeout int,
+	force, dryRun bool) error {
+	phaseHooks := hooks.Get(phase)
+	if len(phaseHooks) == 0 {
+		return nil
+	}
+	ccns := make([]string, 0)
+	for _, ccn := range strings.Split(clusterClaimNames, ",") {
+		ccns = append(ccns, strings.TrimSpace(ccn))
+	}
+	if !dryRun {
+		if err := cph.waitClusterClaimsPowerState(ccns, phase, timeout); err != nil {
+			return err
+		}
+	}
+	for _, ccn := range ccns {
+		var kubeConfig *clientcmdapi.Config
+		if phase.ClusterRunning() && !dryRun {
+			var err error
+			kubeConfig, err = cph.getClusterClaimHookKubeConfig(ccn, timeout)
+			if err != nil {
+				err = fmt.Errorf("%s hooks can not get the context of clusterclaim %s: %v", phase, ccn, err)
+				if !force {
+					return fmt.Errorf("%v, use --force to ignore the hook failures", err)
+				}
+				// without the context the hooks would target the current cluster of the user
+				fmt.Fprintf(os.Stderr, "Warning: %v, the hooks are skipped\n", err)
+				continue
+			}
+		}
+		if err := helpers.RunHooks(phaseHooks, phase, ccn, kubeConfig, force, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitClusterClaimsPowerState waits until the clusterdeployments of the clusterclaims reach the power state
+// of a post phase.
+func (cph *ClusterPoolHost) waitClusterClaimsPowerState(clusterClaimNames []string, phase helpers.HookPhase, timeout int) error {
+	var powerState hivev1.ClusterPowerState
+	switch phase {
+	case helpers.HookPhasePostHibernate:
+		powerState = hivev1.ClusterPowerStateHibernating
+	case helpers.HookPhasePostRun:
+		powerState = hivev1.ClusterPowerStateRunning
+	default:
+		return nil
+	}
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+	cdns := make([]string, 0)
+	for _, ccn := range clusterClaimNames {
+		ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Get(context.TODO(), ccn, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cc := &hivev1.ClusterClaim{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), cc); err != nil {
+			return err
+		}
+		if len(cc.Spec.Namespace) == 0 {
+			return fmt.Errorf("something wrong happened, the clusterclaim %s doesn't have a spec.namespace set", cc.Name)
+		}
+		cdns = append(cdns, cc.Spec.Namespace)
+	}
+	return clusterdeployment.WaitPowerState(dynamicClient, cdns, powerState, timeout)
+}
+
+// getClusterClaimHookKubeConfig returns a kubeconfig containing only the context of the clusterclaim,
+// the context is created by SetClusterClaimContext if it doesn't exist yet.
+// The context is only created for a running cluster, the hooks never resume a hibernated cluster.
+func (cph *ClusterPoolHost) getClusterClaimHookKubeConfig(clusterClaimName string, timeout int) (*clientcmdapi.Config, error) {
+	configAPI, _, err := GetConfigAPI()
+	if err != nil {
+		return nil, err
+	}
+	contextName := cph.GetClusterContextName(clusterClaimName)
+	if _, ok := configAPI.Contexts[contextName]; !ok {
+		if err := cph.checkClusterClaimRunning(clusterClaimName); err != nil {
+			return nil, err
+		}
+		outputFormat := "yaml"
+		if err := cph.SetClusterClaimContext(clusterClaimName, false, timeout, false, "", &get.PrintFlags{OutputFormat: &outputFormat}); err != nil {
+			return nil, err
+		}
+		configAPI, _, err = GetConfigAPI()
+		if err != nil {
+			return nil, err
+		}
+	}
+	configAPI.CurrentContext = contextName
+	if err := clientcmdapi.MinifyConfig(configAPI); err != nil {
+		return nil, err
+	}
+	return configAPI, nil
+}
+
+// checkClusterClaimRunning returns an error if the clusterdeployment of the clusterclaim is not running
+func (cph *ClusterPoolHost) checkClusterClaimRunning(clusterClaimName string) error {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+	ccu, err := dynamicClient.Resource(helpers.GvrCC).Namespace(cph.Namespace).Get(context.TODO(), clusterClaimName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cc := &hivev1.ClusterClaim{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), cc); err != nil {
+		return err
+	}
+	if len(cc.Spec.Namespace) == 0 {
+		return fmt.Errorf("something wrong happened, the clusterclaim %s doesn't have a spec.namespace set", cc.Name)
+	}
+	cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(cc.Spec.Namespace).Get(context.TODO(), cc.Spec.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	cd := &hivev1.ClusterDeployment{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(cdu.UnstructuredContent(), cd); err != nil {
+		return err
+	}
+	if status := clusterdeployment.GetPowerStateStatus(cd, hivev1.ClusterPowerStateRunning); !status.Done {
+		return fmt.Errorf("the clusterclaim %s is %s, its context can not be created, run `cm use cc %s` once it is running", clusterClaimName, status.Phase, clusterClaimName)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WriteClusterClaimLogs writes the provision or deprovision logs of the cluster claimed by a clusterclaim
+// to out and the status lines to errOut
+func (cph *ClusterPoolHost) WriteClusterClaimLogs(clusterClaimName string, o *clusterdeployment.LogsOptions, out, errOut io.Writer) error {
+	cc, err := cph.GetClusterClaim(clusterClaimName, false, 0, false, nil)
+	if err != nil {
+		return err
+	}
+	if len(cc.Spec.Namespace) == 0 {
+		return fmt.Errorf("the clusterclaim %s doesn't have a spec.namespace set yet, no cluster is assigned", cc.Name)
+	}
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return err
+	}
+	return clusterdeployment.WriteLogs(dynamicClient, kubeClient, cc.Spec.Namespace, o, out, errOut)
+}
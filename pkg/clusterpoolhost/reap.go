@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// KeepUntilAnnotation protects a clusterclaim from the reaper until the RFC3339 time it contains,
+	// the value "always" protects it forever.
+	KeepUntilAnnotation = "cm-cli.open-cluster-management.io/keep-until"
+
+	ReapActionHibernate = "hibernate"
+	ReapActionDelete    = "delete"
+)
+
+// ReapOptions are the filters selecting the clusterclaims to reap, all set filters must match
+type ReapOptions struct {
+	// Select the clusterclaims created before that duration
+	OlderThan time.Duration
+	// Select the clusterclaims of this clusterpool
+	ClusterPoolName string
+	// Select the clusterclaims hibernating for more than that duration
+	IdleHibernatedFor time.Duration
+	// Select the clusterclaims having this user or service account as subject
+	Owner string
+}
+
+// ReapCandidate is a clusterclaim matching the reap filters
+type ReapCandidate struct {
+	ClusterClaim *hivev1.ClusterClaim
+	PowerState   string
+	// The reason why the clusterclaim is not reaped, empty if it will be reaped
+	SkipReason string
+}
+
+// GetClusterClaimsToReap returns the clusterclaims matching the reap filters
+func (cph *ClusterPoolHost) GetClusterClaimsToReap(o *ReapOptions) ([]ReapCandidate, error) {
+	clusterPoolRestConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(clusterPoolRestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return getClusterClaimsToReap(dynamicClient, cph.Namespace, o, time.Now())
+}
+
+func getClusterClaimsToReap(dynamicClient dynamic.Interface, namespace string, o *ReapOptions, now time.Time) ([]ReapCandidate, error) {
+	l, err := dynamicClient.Resource(helpers.GvrCC).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]ReapCandidate, 0)
+	for _, ccu := range l.Items {
+		cc := &hivev1.ClusterClaim{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ccu.UnstructuredContent(), cc); err != nil {
+			return nil, err
+		}
+		if !cc.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if len(o.ClusterPoolName) != 0 && cc.Spec.ClusterPoolName != o.ClusterPoolName {
+			continue
+		}
+		if o.OlderThan > 0 && now.Sub(cc.CreationTimestamp.Time) < o.OlderThan {
+			continue
+		}
+		if len(o.Owner) != 0 && !hasOwner(cc.Spec.Subjects, o.Owner) {
+			continue
+		}
+		var cd *hivev1.ClusterDeployment
+		if len(cc.Spec.Namespace) != 0 {
+			cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(cc.Spec.Namespace).Get(context.TODO(), cc.Spec.Namespace, metav1.GetOptions{})
+			if err == nil {
+				cd = &hivev1.ClusterDeployment{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(cdu.UnstructuredContent(), cd); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if o.IdleHibernatedFor > 0 && !isHibernatedFor(cd, o.IdleHibernatedFor, now) {
+			continue
+		}
+		candidate := ReapCandidate{
+			ClusterClaim: cc,
+		}
+		if cd != nil {
+			candidate.PowerState = string(cd.Spec.PowerState)
+		}
+		switch keepUntil := cc.GetAnnotations()[KeepUntilAnnotation]; {
+		case keepUntil == "always":
+			candidate.SkipReason = "kept always"
+		case len(keepUntil) != 0:
+			t, err := time.Parse(time.RFC3339, keepUntil)
+			if err != nil {
+				candidate.SkipReason = fmt.Sprintf("invalid %s annotation: %s", KeepUntilAnnotation, keepUntil)
+			} else if now.Before(t) {
+				candidate.SkipReason = fmt.Sprintf("kept until %s", t.Local().Format(time.RFC3339))
+			}
+		}
+		if lease := GetLease(cc); len(candidate.SkipReason) == 0 && lease.IsActive(now) {
+			candidate.SkipReason = fmt.Sprintf("checked out by %s", lease)
+		}
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ClusterClaim.Name < candidates[j].ClusterClaim.Name
+	})
+	return candidates, nil
+}
+
+// ReapClusterClaims hibernates or deletes the candidates which are not skipped
+func (cph *ClusterPoolHost) ReapClusterClaims(candidates []ReapCandidate, action string, dryRun bool) error {
+	names := make([]string, 0)
+	for _, c := range candidates {
+		if len(c.SkipReason) != 0 {
+			continue
+		}
+		if action == ReapActionHibernate && c.PowerState == string(hivev1.ClusterPowerStateHibernating) {
+			continue
+		}
+		names = append(names, c.ClusterClaim.Name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	switch action {
+	case ReapActionHibernate:
+		return cph.HibernateClusterClaims(strings.Join(names, ","), "", nil, 0, false, dryRun)
+	case ReapActionDelete:
+		return cph.DeleteClusterClaims(strings.Join(names, ","), false, dryRun)
+	}
+	return fmt.Errorf("unsupported action %s", action)
+}
+
+func hasOwner(subjects []rbacv1.Subject, owner string) bool {
+	for _, s := range subjects {
+		if s.Kind != rbacv1.GroupKind && s.Name == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// isHibernatedFor returns true if the cluster is hibernating since at least the duration
+func isHibernatedFor(cd *hivev1.ClusterDeployment, d time.Duration, now time.Time) bool {
+	if cd == nil || cd.Spec.PowerState != hivev1.ClusterPowerStateHibernating {
+		return false
+	}
+	for _, c := range cd.Status.Conditions {
+		if c.Type == hivev1.ClusterHibernatingCondition && c.Status == corev1.ConditionTrue {
+			return now.Sub(c.LastTransitionTime.Time) >= d
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpoolhost
+
+import (
+	"testing"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetClusterClaimsToReap(t *testing.T) {
+	now := time.Now()
+	newCC := func(name, pool string, age time.Duration, annotations map[string]string) *hivev1.ClusterClaim {
+		return &hivev1.ClusterClaim{
+			TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterClaim"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "mynamespace",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				Annotations:       annotations,
+			},
+			Spec: hivev1.ClusterClaimSpec{
+				ClusterPoolName: pool,
+				Namespace:       name + "-cd",
+				Subjects: []rbacv1.Subject{
+					{Kind: rbacv1.ServiceAccountKind, Name: name + "-owner", Namespace: "mynamespace"},
+					{Kind: rbacv1.GroupKind, Name: "mygroup"},
+				},
+			},
+		}
+	}
+	newCD := func(name string, powerState hivev1.ClusterPowerState, hibernatedFor time.Duration) *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "hive.openshift.io/v1", Kind: "ClusterDeployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-cd",
+				Namespace: name + "-cd",
+			},
+			Spec: hivev1.ClusterDeploymentSpec{
+				PowerState: powerState,
+			},
+		}
+		if powerState == hivev1.ClusterPowerStateHibernating {
+			cd.Status.Conditions = []hivev1.ClusterDeploymentCondition{
+				{
+					Type:               hivev1.ClusterHibernatingCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-hibernatedFor)),
+				},
+			}
+		}
+		return cd
+	}
+	objs := []runtime.Object{
+		newCC("old", "pool1", 72*time.Hour, nil),
+		newCD("old", hivev1.ClusterPowerStateRunning, 0),
+		newCC("young", "pool1", time.Hour, nil),
+		newCD("young", hivev1.ClusterPowerStateRunning, 0),
+		newCC("otherpool", "pool2", 72*time.Hour, nil),
+		newCD("otherpool", hivev1.ClusterPowerStateHibernating, 24*time.Hour),
+		newCC("kept", "pool1", 72*time.Hour, map[string]string{KeepUntilAnnotation: now.Add(time.Hour).UTC().Format(time.RFC3339)}),
+		newCD("kept", hivev1.ClusterPowerStateRunning, 0),
+		newCC("keepexpired", "pool1", 72*time.Hour, map[string]string{KeepUntilAnnotation: now.Add(-time.Hour).UTC().Format(time.RFC3339)}),
+		newCD("keepexpired", hivev1.ClusterPowerStateHibernating, 2*time.Hour),
+		newCC("leased", "pool2", 72*time.Hour, map[string]string{
+			LeaseHolderAnnotation: "me",
+			LeaseExpiryAnnotation: now.Add(time.Hour).UTC().Format(time.RFC3339),
+		}),
+		newCD("leased", hivev1.ClusterPowerStateRunning, 0),
+	}
+	dynamicClient := newFakeDynamicClient(t, objs...)
+
+	tests := []struct {
+		name string
+		o    *ReapOptions
+		// clusterclaim name -> skipped
+		want map[string]bool
+	}{
+		{
+			name: "older than",
+			o:    &ReapOptions{OlderThan: 48 * time.Hour},
+			want: map[string]bool{"old": false, "otherpool": false, "kept": true, "keepexpired": false, "leased": true},
+		},
+		{
+			name: "older than in a pool",
+			o:    &ReapOptions{OlderThan: 48 * time.Hour, ClusterPoolName: "pool1"},
+			want: map[string]bool{"old": false, "kept": true, "keepexpired": false},
+		},
+		{
+			name: "idle hibernated",
+			o:    &ReapOptions{IdleHibernatedFor: 12 * time.Hour},
+			want: map[string]bool{"otherpool": false},
+		},
+		{
+			name: "owner",
+			o:    &ReapOptions{OlderThan: time.Minute, Owner: "young-owner"},
+			want: map[string]bool{"young": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getClusterClaimsToReap(dynamicClient, "mynamespace", tt.o, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %d candidates, want %d", len(got), len(tt.want))
+			}
+			for _, c := range got {
+				skipped, ok := tt.want[c.ClusterClaim.Name]
+				if !ok {
+					t.Errorf("unexpected candidate %s", c.ClusterClaim.Name)
+					continue
+				}
+				if skipped != (len(c.SkipReason) != 0) {
+					t.Errorf("candidate %s skipped: %t, want %t (%s)", c.ClusterClaim.Name, !skipped, skipped, c.SkipReason)
+				}
+			}
+		})
+	}
+}
@@ -4,12 +4,12 @@ package scenario
 import (
 	"embed"
 
-	"github.com/stolostron/applier/pkg/asset"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 )
 
 //go:embed create create/*/*/_helpers.tpl config
 var files embed.FS
 
-func GetScenarioResourcesReader() *asset.ScenarioResourcesReader {
-	return asset.NewScenarioResourcesReader(&files)
+func GetScenarioResourcesReader() *helpers.ScenarioReader {
+	return helpers.NewScenarioReader("clusterpool", &files)
 }
@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+package agents
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Approve an agent and bind it to a cluster as master
+%[1]s approve agents <agent_name> --cluster <cluster_name> --role master --hostname master-0
+
+# Approve all the agents of the cluster namespace and bind them to the cluster
+%[1]s approve agents --all --cluster <cluster_name>
+
+# Approve several agents of a namespace
+%[1]s approve agents <agent_name>,<agent_name> -n <namespace>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "agents [<agent_name>[,<agent_name>...]]",
+		Aliases:      []string{"agent"},
+		Short:        "approve the agents discovered by the assisted installer and bind them to a cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.cluster, "cluster", "", "The cluster to bind the agents to, the namespace defaults to the cluster name")
+	cmd.Flags().BoolVar(&o.all, "all", false, "Approve all the agents not yet approved")
+	cmd.Flags().StringVar(&o.role, "role", "", "The role of the agents: master, worker or auto-assign")
+	cmd.Flags().StringVar(&o.hostname, "hostname", "", "The hostname of the agent, only when one agent is approved")
+
+	return cmd
+}
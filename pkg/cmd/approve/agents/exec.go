@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+package agents
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/agent"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	namespace, explicit, err := o.CMFlags.KubectlFactory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	if !explicit && len(o.cluster) != 0 {
+		namespace = o.cluster
+	}
+	names := make([]string, 0)
+	for _, arg := range args {
+		for _, name := range strings.Split(arg, ",") {
+			if len(name) != 0 {
+				names = append(names, name)
+			}
+		}
+	}
+	o.approveOptions = agent.ApproveOptions{
+		Namespace: namespace,
+		Names:     names,
+		All:       o.all,
+		Cluster:   o.cluster,
+		Role:      o.role,
+		Hostname:  o.hostname,
+		DryRun:    o.CMFlags.DryRun,
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.approveOptions.Validate()
+}
+
+func (o *Options) run() (err error) {
+	dynamicClient, err := o.CMFlags.KubectlFactory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	agents, err := agent.Approve(dynamicClient, o.approveOptions)
+	for _, a := range agents {
+		if len(a.Cluster) != 0 {
+			fmt.Fprintf(o.streams.Out, "agent %s/%s (%s) approved and bound to the cluster %s\n", a.Namespace, a.Name, a.Hostname, a.Cluster)
+		} else {
+			fmt.Fprintf(o.streams.Out, "agent %s/%s (%s) approved\n", a.Namespace, a.Name, a.Hostname)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		fmt.Fprintln(o.streams.Out, "No agent to approve")
+	}
+	return nil
+}
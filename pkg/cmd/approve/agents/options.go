@@ -0,0 +1,30 @@
+// Copyright Contributors to the Open Cluster Management project
+package agents
+
+import (
+	"github.com/stolostron/cm-cli/pkg/agent"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic options from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The cluster to bind the agents to
+	cluster string
+	//Approve all the agents not yet approved
+	all bool
+	//The role of the agents
+	role string
+	//The hostname of the agent
+	hostname       string
+	approveOptions agent.ApproveOptions
+	streams        genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+	}
+}
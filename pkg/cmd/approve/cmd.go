@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package approve
+
+import (
+	"github.com/stolostron/cm-cli/pkg/cmd/approve/agents"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping NewCmdImportCluster
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve",
+		Short: "approve a resource",
+	}
+
+	cmd.AddCommand(agents.NewCmd(cmFlags, streams))
+
+	return cmd
+}
@@ -33,6 +33,7 @@ const (
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
 var valuesDefaultPath = filepath.Join(scenarioDirectory, "values-default.yaml")
+var valuesSchemaPath = filepath.Join(scenarioDirectory, helpers.ValuesSchemaFileName)
 
 // NewCmd provides a cobra command wrapping NewCmdImportCluster
 func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
@@ -74,7 +75,7 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	}
 
 	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
-	cmd.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cmd.Flags())
 	cmd.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the cluster")
 	cmd.Flags().StringVar(&o.clusterServer, "cluster-server", "", "cluster server url of the cluster to import")
 	cmd.Flags().StringVar(&o.clusterToken, "cluster-token", "", "token to access the cluster to import")
@@ -83,6 +84,8 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd.Flags().StringVar(&o.clusterKubeConfigContent, "cluster-kubeconfig-content", "", "content of the kubeconfig the cluster to import")
 	cmd.Flags().StringVar(&o.importFile, "import-file", "", "the file path and prefix which will contain the import yaml files for manual import")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cmd.Flags())
+	o.exportFlags.AddFlags(cmd.Flags())
 	cmd.Flags().BoolVar(&o.waitAgent, "wait", false, "Wait until the klusterlet agent is installed")
 	//Not implemented as it requires to import all addon packages
 	// cmd.Flags().BoolVar(&o.waitAddOns, "wait-addons", false, "Wait until the klusterlet agent and the addons are is installed")
@@ -33,7 +33,7 @@ var testDir = filepath.Join("test", "unit")
 
 func TestOptions_complete(t *testing.T) {
 	type fields struct {
-		valuesPath        string
+		valuesFlags       helpers.ValuesFlags
 		clusterName       string
 		clusterServer     string
 		clusterToken      string
@@ -52,14 +52,14 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Failed, bad valuesPath",
 			fields: fields{
-				valuesPath: "badpath",
+				valuesFlags: helpers.ValuesFlags{Paths: []string{"badpath"}},
 			},
 			wantErr: true,
 		},
 		{
 			name: "Failed, empty values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-empty.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-empty.yaml")}},
 			},
 			wantErr: true,
 		},
@@ -71,7 +71,7 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Success, not replacing values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-with-data.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-with-data.yaml")}},
 			},
 			wantErr: false,
 		},
@@ -97,7 +97,7 @@ func TestOptions_complete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			o := &Options{
-				valuesPath:        tt.fields.valuesPath,
+				valuesFlags:       tt.fields.valuesFlags,
 				clusterName:       tt.fields.clusterName,
 				clusterServer:     tt.fields.clusterServer,
 				clusterToken:      tt.fields.clusterToken,
@@ -23,7 +23,7 @@ import (
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	//Check if default values must be used
-	if o.valuesPath == "" {
+	if !o.valuesFlags.HasFiles() {
 		if len(args) < 1 {
 			return fmt.Errorf("ClusterClaim name is missing")
 		}
@@ -37,11 +37,14 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 		mc["name"] = o.ClusterClaim
 	} else {
 		//Read values
-		o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+		o.values, err = o.valuesFlags.ReadFiles()
 		if err != nil {
 			return err
 		}
 	}
+	if err = o.valuesFlags.ApplyOverrides(o.values); err != nil {
+		return err
+	}
 
 	imc, ok := o.values["managedCluster"]
 	if !ok || imc == nil {
@@ -219,5 +222,5 @@ func (o *Options) attachClusterClaim(cph *clusterpoolhost.ClusterPoolHost) error
 		}
 	}
 
-	return apply.WriteOutput(o.outputFile, output)
+	return helpers.WriteOutput(o.CMFlags, applierBuilder, o.outputFile, output)
 }
@@ -3,6 +3,7 @@ package clusterclaim
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -14,7 +15,7 @@ type Options struct {
 	waitAgent       bool
 	waitAddOns      bool
 	timeout         int
-	valuesPath      string
+	valuesFlags     helpers.ValuesFlags
 	values          map[string]interface{}
 	//The file to output the resources will be sent to the file.
 	outputFile string
@@ -79,9 +79,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	}
 
 	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
-	cmd.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cmd.Flags())
 	cmd.Flags().StringVarP(&o.HostedClusterNamespace, "namespace", "n", "clusters", "The HostedCluster namespace")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cmd.Flags())
 	cmd.Flags().BoolVar(&o.waitAgent, "wait", false, "Wait until the klusterlet agent is installed")
 	//Not implemented as it requires to import all addon packages
 	// cmd.Flags().BoolVar(&o.waitAddOns, "wait-addons", false, "Wait until the klusterlet agent and the addons are is installed")
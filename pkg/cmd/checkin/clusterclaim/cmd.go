@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Check in clusterclaims
+%[1]s checkin cc <clusterclaim_name>[,<clusterclaim_name>...]
+
+# Check in a clusterclaim on a given clusterpoolhost
+%[1]s checkin cc <clusterclaim_name> --cph <clusterpoolhost> <options>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "clusterclaim",
+		Aliases:      []string{"clusterclaims", "cc", "ccs"},
+		Short:        "check in clusterclaims",
+		Long:         "Release the lease recorded on the clusterclaims by checkout",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Release the lease even if it is held by another user")
+
+	return cmd
+}
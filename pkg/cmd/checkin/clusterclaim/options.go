@@ -0,0 +1,23 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The list of cluster claims to check in (comma-separated)
+	ClusterClaims   string
+	ClusterPoolHost string
+	//Release a lease held by another user
+	Force bool
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+	}
+}
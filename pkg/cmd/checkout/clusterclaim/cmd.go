@@ -0,0 +1,53 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+	"time"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Check out clusterclaims for 4 hours
+%[1]s checkout cc <clusterclaim_name>[,<clusterclaim_name>...] --for 4h --note "perf test"
+
+# Check out a clusterclaim on a given clusterpoolhost
+%[1]s checkout cc <clusterclaim_name> --cph <clusterpoolhost> <options>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "clusterclaim",
+		Aliases:      []string{"clusterclaims", "cc", "ccs"},
+		Short:        "check out clusterclaims",
+		Long:         "Record a lease on the clusterclaims so other users are warned before hibernating or deleting them",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().DurationVar(&o.Duration, "for", 4*time.Hour, "The duration of the lease")
+	cmd.Flags().StringVar(&o.Note, "note", "", "A note explaining why the clusterclaims are checked out")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Take over the lease even if the clusterclaims are checked out by another user")
+
+	return cmd
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("clusterclaim names are missing")
+	}
+	o.ClusterClaims = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.Duration <= 0 {
+		return fmt.Errorf("the lease duration must be positive")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(o.ClusterPoolHost)
+	if err != nil {
+		return err
+	}
+
+	return cph.CheckoutClusterClaims(o.ClusterClaims, o.Duration, o.Note, o.Force, o.CMFlags.DryRun)
+}
@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"time"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The list of cluster claims to check out (comma-separated)
+	ClusterClaims   string
+	ClusterPoolHost string
+	//The duration of the lease
+	Duration time.Duration
+	//The note attached to the lease
+	Note string
+	//Take over a lease held by another user
+	Force bool
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+	}
+}
@@ -4,7 +4,9 @@ package cmd
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -21,23 +23,34 @@ import (
 
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
 
+	"github.com/stolostron/cm-cli/pkg/cmd/approve"
 	"github.com/stolostron/cm-cli/pkg/cmd/attach"
 	"github.com/stolostron/cm-cli/pkg/cmd/bind"
+	"github.com/stolostron/cm-cli/pkg/cmd/checkin"
+	"github.com/stolostron/cm-cli/pkg/cmd/checkout"
 	"github.com/stolostron/cm-cli/pkg/cmd/console"
 	"github.com/stolostron/cm-cli/pkg/cmd/create"
 	"github.com/stolostron/cm-cli/pkg/cmd/delete"
+	"github.com/stolostron/cm-cli/pkg/cmd/describe"
 	"github.com/stolostron/cm-cli/pkg/cmd/detach"
 	"github.com/stolostron/cm-cli/pkg/cmd/disable"
 	"github.com/stolostron/cm-cli/pkg/cmd/enable"
+	"github.com/stolostron/cm-cli/pkg/cmd/explain"
 	"github.com/stolostron/cm-cli/pkg/cmd/get"
 	"github.com/stolostron/cm-cli/pkg/cmd/hibernate"
 	"github.com/stolostron/cm-cli/pkg/cmd/install"
+	"github.com/stolostron/cm-cli/pkg/cmd/logs"
+	"github.com/stolostron/cm-cli/pkg/cmd/profile"
 	"github.com/stolostron/cm-cli/pkg/cmd/proxy"
+	"github.com/stolostron/cm-cli/pkg/cmd/reap"
+	"github.com/stolostron/cm-cli/pkg/cmd/rotate"
 	"github.com/stolostron/cm-cli/pkg/cmd/run"
 	"github.com/stolostron/cm-cli/pkg/cmd/scale"
+	"github.com/stolostron/cm-cli/pkg/cmd/scenario"
 	"github.com/stolostron/cm-cli/pkg/cmd/set"
 	"github.com/stolostron/cm-cli/pkg/cmd/unbind"
 	"github.com/stolostron/cm-cli/pkg/cmd/use"
+	"github.com/stolostron/cm-cli/pkg/cmd/validate"
 	"github.com/stolostron/cm-cli/pkg/cmd/version"
 	"github.com/stolostron/cm-cli/pkg/cmd/with"
 )
@@ -76,6 +89,14 @@ func NewCMCommand() *cobra.Command {
 
 	cmFlags := genericclioptionscm.NewCMFlags(f)
 	cmFlags.AddFlags(flags)
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := cmFlags.ValidateServerDryRun(cmd.Flags()); err != nil {
+			return fmt.Errorf("%s: %v", cmd.CommandPath(), err)
+		}
+		helpers.SetNotifier(cmFlags.Notify, strings.TrimSpace(fmt.Sprintf("%s %s", cmd.CommandPath(), strings.Join(args, " "))))
+		helpers.SetScenarioDir(cmFlags.ScenarioDir)
+		return nil
+	}
 
 	// root.AddCommand(cmdconfig.NewCmdConfig(f, clientcmd.NewDefaultPathOptions(), streams))
 	root.AddCommand(options.NewCmdOptions(streams.Out))
@@ -89,6 +110,10 @@ func NewCMCommand() *cobra.Command {
 			Message: "General commands:",
 			Commands: []*cobra.Command{
 				version.NewCmd(cmFlags, streams),
+				validate.NewCmd(cmFlags, streams),
+				explain.NewCmd(cmFlags, streams),
+				profile.NewCmd(cmFlags, streams),
+				scenario.NewCmd(cmFlags, streams),
 			},
 		},
 		{
@@ -99,11 +124,15 @@ func NewCMCommand() *cobra.Command {
 				create.NewCmd(clusteradmFlags, cmFlags, streams),
 				delete.NewCmd(clusteradmFlags, cmFlags, streams),
 				scale.NewCmd(cmFlags, streams),
+				rotate.NewCmd(cmFlags, streams),
 				enable.NewCmd(clusteradmFlags, cmFlags, streams),
 				disable.NewCmd(clusteradmFlags, cmFlags, streams),
 				get.NewCmd(f, clusteradmFlags, cmFlags, streams),
+				describe.NewCmd(cmFlags, streams),
+				logs.NewCmd(cmFlags, streams),
 				bind.NewCmd(clusteradmFlags, cmFlags, streams),
 				unbind.NewCmd(clusteradmFlags, cmFlags, streams),
+				approve.NewCmd(cmFlags, streams),
 				proxy.NewCmd(clusteradmFlags, cmFlags, streams),
 			},
 		},
@@ -114,6 +143,9 @@ func NewCMCommand() *cobra.Command {
 				set.NewCmd(clusteradmFlags, cmFlags, streams),
 				run.NewCmd(cmFlags, streams),
 				hibernate.NewCmd(cmFlags, streams),
+				checkout.NewCmd(cmFlags, streams),
+				checkin.NewCmd(cmFlags, streams),
+				reap.NewCmd(cmFlags, streams),
 				console.NewCmd(cmFlags, streams),
 				with.NewCmd(cmFlags, streams),
 			},
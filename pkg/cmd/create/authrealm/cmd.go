@@ -23,6 +23,7 @@ const (
 )
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+var valuesSchemaPath = filepath.Join(scenarioDirectory, helpers.ValuesSchemaFileName)
 
 var example = `
 # Create a authrealm
@@ -88,8 +89,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd.Flags().StringVar(&o.placement, "placement", "", "The name of the placement")
 	cmd.Flags().StringVar(&o.managedClusterSet, "cluster-set", "", "The name of the managed cluster set")
 	cmd.Flags().StringVar(&o.managedClusterSetBinding, "cluster-set-binding", "", "The of the cluster set binding")
-	cmd.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cmd.Flags())
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cmd.Flags())
+	o.exportFlags.AddFlags(cmd.Flags())
 	cmd.Flags().BoolVar(&o.skipIDPCheck, "skip-idp-check", false, "Skips check if IDP is installed when set")
 
 	return cmd
@@ -18,7 +18,10 @@ import (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+	if o.exportFlags.IsSet() {
+		o.CMFlags.DryRun = true
+	}
+	o.values, err = o.valuesFlags.ToValuesMap()
 	if err != nil {
 		return err
 	}
@@ -61,6 +64,13 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 }
 
 func (o *Options) validate() (err error) {
+	if err := o.exportFlags.Validate(); err != nil {
+		return err
+	}
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+
 	_, apiExtensionClient, dynamicClient, err := clusteradmhelpers.GetClients(o.CMFlags.KubectlFactory)
 	if err != nil {
 		return err
@@ -209,5 +219,8 @@ func (o *Options) runWithClient(restConfig *rest.Config) (err error) {
 	}
 	output = append(output, out...)
 
-	return apply.WriteOutput(o.outputFile, output)
+	if err := o.exportFlags.Export(output); err != nil {
+		return err
+	}
+	return helpers.WriteOutput(o.CMFlags, applierBuilder, o.outputFile, output)
 }
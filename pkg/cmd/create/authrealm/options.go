@@ -3,6 +3,7 @@ package authrealm
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -16,11 +17,13 @@ type Options struct {
 	placement                string
 	managedClusterSet        string
 	managedClusterSetBinding string
-	valuesPath               string
+	valuesFlags              helpers.ValuesFlags
 	values                   map[string]interface{}
 	//The file to output the resources will be sent to the file.
 	outputFile   string
 	skipIDPCheck bool
+	//The directory to export the resources for GitOps instead of applying them
+	exportFlags helpers.ExportFlags
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
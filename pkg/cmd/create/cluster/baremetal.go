@@ -0,0 +1,44 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/topology"
+)
+
+// validateBaremetal checks the values of an agent-based installation, the hosts are provided by the agents
+// so there are no credentials and the master and worker replicas are the number of agents to approve
+func (o *Options) validateBaremetal() error {
+	if len(o.credentials) != 0 {
+		return fmt.Errorf("credentials are not supported by the %s cloud", BAREMETAL)
+	}
+	for _, field := range []string{"managedCluster.baremetal.baseDnsDomain", "managedCluster.sshPublicKey"} {
+		if v, _ := helpers.NestedString(o.values, field); len(strings.TrimSpace(v)) == 0 {
+			return fmt.Errorf("%s is required by the %s cloud", field, BAREMETAL)
+		}
+	}
+	masters, err := topology.Replicas(o.values, "managedCluster.master.replicas", 3)
+	if err != nil {
+		return err
+	}
+	workers, err := topology.Replicas(o.values, "managedCluster.worker.replicas", 0)
+	if err != nil {
+		return err
+	}
+	switch {
+	case masters != 1 && masters != 3:
+		return fmt.Errorf("the %s cloud supports 1 or 3 masters and got %d", BAREMETAL, masters)
+	case masters == 1 && workers != 0:
+		return fmt.Errorf("a single node %s cluster can not have workers and got %d", BAREMETAL, workers)
+	case masters == 3:
+		for _, field := range []string{"managedCluster.baremetal.apiVIP", "managedCluster.baremetal.ingressVIP"} {
+			if v, _ := helpers.NestedString(o.values, field); len(v) == 0 {
+				return fmt.Errorf("%s is required by a multi-node %s cluster", field, BAREMETAL)
+			}
+		}
+	}
+	return nil
+}
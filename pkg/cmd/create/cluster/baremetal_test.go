@@ -0,0 +1,105 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/topology"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+// applyHubDryRun validates the values files and renders the hub resources by kind
+func applyHubDryRun(t *testing.T, values map[string]interface{}) map[string]*unstructured.Unstructured {
+	o := &Options{
+		CMFlags: &genericclioptionscm.CMFlags{DryRun: true},
+		values:  values,
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	o.values["pullSecret"] = map[string]interface{}{
+		"data": map[string]interface{}{".dockerconfigjson": "e30="},
+	}
+	applier := apply.NewApplierBuilder().
+		WithClient(fakekubernetes.NewSimpleClientset(), nil, fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())).
+		Build()
+	output, err := o.applyHub(applier, scenario.GetScenarioResourcesReader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources := make(map[string]*unstructured.Unstructured)
+	for _, out := range output {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(out), &u.Object); err != nil {
+			t.Fatalf("unable to parse %s: %v", out, err)
+		}
+		resources[u.GetKind()] = u
+	}
+	return resources
+}
+
+func readValues(t *testing.T, file string) map[string]interface{} {
+	valuesFlags := helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, file)}}
+	values, err := valuesFlags.ToValuesMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+func TestOptions_applyHub_baremetal(t *testing.T) {
+	resources := applyHubDryRun(t, readValues(t, "values-fake-baremetal.yaml"))
+	for _, kind := range []string{"Namespace", "Secret", "ClusterImageSet", "AgentClusterInstall", "ClusterDeployment", "InfraEnv"} {
+		if _, ok := resources[kind]; !ok {
+			t.Errorf("%s not rendered", kind)
+		}
+	}
+	if _, ok := resources["MachinePool"]; ok {
+		t.Error("no MachinePool must be rendered for a baremetal cluster")
+	}
+
+	cd := resources["ClusterDeployment"]
+	if cd == nil {
+		t.FailNow()
+	}
+	if _, ok, _ := unstructured.NestedMap(cd.Object, "spec", "provisioning"); ok {
+		t.Error("the clusterdeployment must not have a provisioning section")
+	}
+	if kind, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterInstallRef", "kind"); kind != "AgentClusterInstall" {
+		t.Errorf("expected the AgentClusterInstall clusterInstallRef, got %q", kind)
+	}
+	selector, _, _ := unstructured.NestedStringMap(cd.Object, "spec", "platform", "agentBareMetal", "agentSelector", "matchLabels")
+	if selector["site"] != "edge-1" {
+		t.Errorf("expected the agentLabels as agentSelector, got %v", selector)
+	}
+
+	aci := resources["AgentClusterInstall"]
+	if masters, _ := topology.Replicas(aci.Object, "spec.provisionRequirements.controlPlaneAgents", -1); masters != 3 {
+		t.Errorf("expected 3 controlPlaneAgents, got %d", masters)
+	}
+	if workers, _ := topology.Replicas(aci.Object, "spec.provisionRequirements.workerAgents", -1); workers != 2 {
+		t.Errorf("expected 2 workerAgents, got %d", workers)
+	}
+	if imageSet, _, _ := unstructured.NestedString(aci.Object, "spec", "imageSetRef", "name"); !strings.HasPrefix(imageSet, "4.10.20-x86-64") {
+		t.Errorf("expected the imageSetRef of the ocpImage, got %s", imageSet)
+	}
+
+	infraEnv := resources["InfraEnv"]
+	labels, _, _ := unstructured.NestedStringMap(infraEnv.Object, "spec", "agentLabels")
+	if labels["site"] != "edge-1" {
+		t.Errorf("expected the agentLabels on the infraenv, got %v", labels)
+	}
+	if key, _, _ := unstructured.NestedString(infraEnv.Object, "spec", "sshAuthorizedKey"); key != "ssh-rsa Public key" {
+		t.Errorf("expected the sshPublicKey, got %q", key)
+	}
+}
@@ -23,6 +23,7 @@ const (
 )
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+var valuesSchemaPath = filepath.Join(scenarioDirectory, helpers.ValuesSchemaFileName)
 
 var example = `
 # Create a cluster
@@ -33,6 +34,12 @@ var example = `
 
 # Create a cluster with cluster name overwrite by args
 %[1]s create cluster mycluster --values values.yaml
+
+# Create a cluster using the keys of credentials created with '%[1]s create credentials'
+%[1]s create cluster mycluster --values values.yaml --credentials [<namespace>/]<credentials_name>
+
+# Create a cluster from a base values file, a cluster specific one and overrides
+%[1]s create cluster -f aws-base.yaml -f mycluster.yaml --set managedCluster.worker.replicas=5
 `
 
 // NewCmd ...
@@ -75,8 +82,13 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 
 	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
 	cmd.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the cluster")
-	cmd.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.profile, "profile", "", "The profile, saved with 'profile save', layered beneath the values files and the --set options")
+	cmd.Flags().StringVar(&o.credentials, "credentials", "", "The [<namespace>/]<name> of the credentials to use instead of the keys in the values")
+	cmd.Flags().BoolVar(&o.interactive, "interactive", false, "Ask the values missing from the values files field by field, then create or write them to a file")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cmd.Flags())
+	o.exportFlags.AddFlags(cmd.Flags())
 	cmd.Flags().BoolVar(&o.waitAgent, "wait", false, "Wait until the klusterlet agent is installed")
 	//Not implemented as it requires to import all addon packages
 	// cmd.Flags().BoolVar(&o.waitAddOns, "wait-addons", false, "Wait until the klusterlet agent and the addons are is installed")
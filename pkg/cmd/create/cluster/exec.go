@@ -10,9 +10,13 @@ import (
 	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
 
 	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/applier/pkg/asset"
 	attachscenario "github.com/stolostron/cm-cli/pkg/cmd/attach/cluster/scenario"
 	"github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	"github.com/stolostron/cm-cli/pkg/credentials"
 	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/profile"
+	"github.com/stolostron/cm-cli/pkg/topology"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes"
@@ -29,26 +33,64 @@ const (
 	GCP       = "gcp"
 	OPENSTACK = "openstack"
 	VSPHERE   = "vsphere"
+	BAREMETAL = "baremetal"
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+	if o.exportFlags.IsSet() {
+		o.CMFlags.DryRun = true
+	}
+	// the --interactive mode keeps the references to write them in the values file
+	o.valuesFlags.KeepReferences = o.interactive
+	if len(o.profile) != 0 {
+		if o.interactive {
+			p, err := profile.Get(o.profile)
+			if err != nil {
+				return err
+			}
+			o.valuesFlags.Base = p.Values
+		} else {
+			o.valuesFlags.Base, err = profile.GetValues(o.profile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	o.valuesFlags.NoStdin = o.interactive
+	o.values, err = o.valuesFlags.ToValuesMap()
 	if err != nil {
 		return err
 	}
 
-	if len(o.values) == 0 {
-		return fmt.Errorf("values are missing")
-	}
-
 	if len(args) > 0 {
 		o.clusterName = args[0]
 	}
 
+	if o.interactive {
+		if err := o.interact(); err != nil {
+			return err
+		}
+	}
+
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+
 	return nil
 }
 
 func (o *Options) validate() (err error) {
+	if err := o.exportFlags.Validate(); err != nil {
+		return err
+	}
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+
+	if len(o.credentials) == 0 {
+		o.credentials = credentials.RefFromValues(o.values)
+	}
+
 	_, ok, err := unstructured.NestedFieldNoCopy(o.values, "managedCluster")
 	if err != nil {
 		return err
@@ -65,9 +107,20 @@ func (o *Options) validate() (err error) {
 		o.cloud != AZURE &&
 		o.cloud != GCP &&
 		o.cloud != OPENSTACK &&
-		o.cloud != VSPHERE {
-		return fmt.Errorf("supported cloud type are (%s, %s, %s, %s, %s) and got %s",
-			AWS, AZURE, GCP, OPENSTACK, VSPHERE, o.cloud)
+		o.cloud != VSPHERE &&
+		o.cloud != BAREMETAL {
+		return fmt.Errorf("supported cloud type are (%s, %s, %s, %s, %s, %s) and got %s",
+			AWS, AZURE, GCP, OPENSTACK, VSPHERE, BAREMETAL, o.cloud)
+	}
+
+	if _, err := topology.Apply(o.values, "managedCluster", o.cloud); err != nil {
+		return err
+	}
+
+	if o.cloud == BAREMETAL {
+		if err := o.validateBaremetal(); err != nil {
+			return err
+		}
 	}
 
 	ocpImageOk, _ := helpers.NestedExists(o.values, "managedCluster.ocpImageOk")
@@ -95,10 +148,17 @@ func (o *Options) validate() (err error) {
 		return err
 	}
 
+	if o.interactive {
+		return o.confirm()
+	}
+
 	return nil
 }
 
 func (o *Options) run() error {
+	if o.skipRun {
+		return nil
+	}
 	restConfig, err := o.CMFlags.KubectlFactory.ToRESTConfig()
 	if err != nil {
 		return err
@@ -118,6 +178,81 @@ func (o *Options) run() error {
 	return o.runWithClient(restConfig, kubeClient, clusterClient, workClient)
 }
 
+// applyHub applies the namespace, secrets, clusterimageset and clusterdeployment of the cluster
+func (o *Options) applyHub(applier apply.Applier, reader asset.ScenarioReader) ([]string, error) {
+	output := make([]string, 0)
+	// The baremetal clusters are installed by the assisted installer from an AgentClusterInstall
+	// instead of an install-config
+	var valueic map[string]interface{}
+	if o.cloud != BAREMETAL {
+		installConfig, err := applier.MustTemplateAsset(reader,
+			o.values,
+			"",
+			filepath.Join(scenarioDirectory, "hub", o.cloud, "install_config.yaml"))
+		if err != nil {
+			return output, err
+		}
+
+		valueic = make(map[string]interface{})
+		err = yaml.Unmarshal(installConfig, &valueic)
+		if err != nil {
+			return output, err
+		}
+	}
+
+	files := []string{
+		"create/hub/common/namespace.yaml",
+	}
+
+	out, err := applier.ApplyDirectly(reader, o.values, o.CMFlags.DryRun, "", files...)
+	if err != nil {
+		return output, err
+	}
+	output = append(output, out...)
+
+	if o.cloud == BAREMETAL {
+		files = []string{
+			"create/hub/common/pull_secret_cr.yaml",
+			"create/hub/baremetal/agent_cluster_install_cr.yaml",
+		}
+	} else {
+		o.values["installConfig"] = valueic
+		files = []string{
+			"create/hub/common/creds_secret_cr.yaml",
+			"create/hub/common/install_config_secret_cr.yaml",
+		}
+		// The sno and compact clusters have no worker, the masters are schedulable
+		workers, err := topology.Replicas(o.values, "managedCluster.worker.replicas", 3)
+		if err != nil {
+			return output, err
+		}
+		if workers != 0 {
+			files = append(files, "create/hub/common/machinepool_cr.yaml")
+		}
+		files = append(files,
+			"create/hub/common/pull_secret_cr.yaml",
+			"create/hub/common/ssh_private_key_secret_cr.yaml",
+			"create/hub/common/vsphere_ca_cert_secret_cr.yaml")
+	}
+
+	imc := o.values["managedCluster"]
+	mc := imc.(map[string]interface{})
+
+	if _, ok := mc["imageSetRef"]; !ok {
+		files = append(files, "create/hub/common/clusterimageset_cr.yaml")
+	}
+
+	files = append(files, "create/hub/common/cluster_deployment_cr.yaml")
+	if o.cloud == BAREMETAL {
+		files = append(files, "create/hub/baremetal/infraenv_cr.yaml")
+	}
+	out, err = applier.ApplyCustomResources(reader, o.values, o.CMFlags.DryRun, "create/hub/common/_helpers.tpl", files...)
+	if err != nil {
+		return output, err
+	}
+	return append(output, out...), nil
+}
+
 func (o *Options) runWithClient(
 	restConfig *rest.Config,
 	kubeClient kubernetes.Interface,
@@ -145,60 +280,33 @@ func (o *Options) runWithClient(
 
 	o.values["pullSecret"] = valueps
 
+	if len(o.credentials) != 0 {
+		defaultNamespace, _, err := o.CMFlags.KubectlFactory.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return err
+		}
+		namespace, name := credentials.ParseRef(o.credentials, defaultNamespace)
+		secret, err := credentials.Get(kubeClient, namespace, name)
+		if err != nil {
+			return err
+		}
+		if err := credentials.SetValues(secret, o.cloud, "managedCluster", o.values); err != nil {
+			return err
+		}
+	}
+
 	reader := scenario.GetScenarioResourcesReader()
 	attachreader := attachscenario.GetScenarioResourcesReader()
 	applierBuilder := apply.NewApplierBuilder()
 	applier := applierBuilder.WithRestConfig(restConfig).Build()
 
-	installConfig, err := applier.MustTemplateAsset(reader,
-		o.values,
-		"",
-		filepath.Join(scenarioDirectory, "hub", o.cloud, "install_config.yaml"))
-	if err != nil {
-		return err
-	}
-
-	valueic := make(map[string]interface{})
-	err = yaml.Unmarshal(installConfig, &valueic)
-	if err != nil {
-		return err
-	}
-
-	files := []string{
-		"create/hub/common/namespace.yaml",
-	}
-
-	out, err := applier.ApplyDirectly(reader, o.values, o.CMFlags.DryRun, "", files...)
+	out, err := o.applyHub(applier, reader)
 	if err != nil {
 		return err
 	}
 	output = append(output, out...)
-	o.values["installConfig"] = valueic
 
-	files = []string{
-		"create/hub/common/creds_secret_cr.yaml",
-		"create/hub/common/install_config_secret_cr.yaml",
-		"create/hub/common/machinepool_cr.yaml",
-		"create/hub/common/pull_secret_cr.yaml",
-		"create/hub/common/ssh_private_key_secret_cr.yaml",
-		"create/hub/common/vsphere_ca_cert_secret_cr.yaml",
-	}
-
-	imc := o.values["managedCluster"]
-	mc := imc.(map[string]interface{})
-
-	if _, ok := mc["imageSetRef"]; !ok {
-		files = append(files, "create/hub/common/clusterimageset_cr.yaml")
-	}
-
-	files = append(files, "create/hub/common/cluster_deployment_cr.yaml")
-	out, err = applier.ApplyCustomResources(reader, o.values, o.CMFlags.DryRun, "create/hub/common/_helpers.tpl", files...)
-	if err != nil {
-		return err
-	}
-	output = append(output, out...)
-
-	files = []string{
+	files := []string{
 		"attach/hub/managed_cluster_cr.yaml",
 	}
 
@@ -221,5 +329,8 @@ func (o *Options) runWithClient(
 			return helpers.WaitKlusterletAddons(workClient, o.clusterName, o.timeout)
 		}
 	}
-	return apply.WriteOutput(o.outputFile, output)
+	if err := o.exportFlags.Export(output); err != nil {
+		return err
+	}
+	return helpers.WriteOutput(o.CMFlags, applierBuilder, o.outputFile, output)
 }
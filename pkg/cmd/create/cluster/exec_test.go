@@ -2,8 +2,12 @@
 package cluster
 
 import (
+	"bytes"
 	"context"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -11,7 +15,9 @@ import (
 
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/topology"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/spf13/cobra"
 	cligenericclioptions "k8s.io/cli-runtime/pkg/genericclioptions"
@@ -29,7 +35,7 @@ func TestOptions_complete(t *testing.T) {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
 		cloud       string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 		outputFile  string
 	}
@@ -46,14 +52,14 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Failed, empty values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-empty.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-empty.yaml")}},
 			},
 			wantErr: true,
 		},
 		{
 			name: "Sucess, with values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-fake-aws.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-fake-aws.yaml")}},
 			},
 			wantErr: false,
 		},
@@ -64,7 +70,7 @@ func TestOptions_complete(t *testing.T) {
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
 				cloud:       tt.fields.cloud,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 				outputFile:  tt.fields.outputFile,
 			}
@@ -80,7 +86,7 @@ func TestOptions_validate(t *testing.T) {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
 		cloud       string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 		outputFile  string
 	}
@@ -137,10 +143,93 @@ func TestOptions_validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Success Baremetal all info in values",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":         "test",
+						"cloud":        "baremetal",
+						"sshPublicKey": "ssh-rsa key",
+						"baremetal": map[string]interface{}{
+							"baseDnsDomain": "example.com",
+							"apiVIP":        "192.168.111.5",
+							"ingressVIP":    "192.168.111.4",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success Baremetal single node without VIPs",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":         "test",
+						"cloud":        "baremetal",
+						"sshPublicKey": "ssh-rsa key",
+						"master":       map[string]interface{}{"replicas": 1},
+						"worker":       map[string]interface{}{"replicas": 0},
+						"baremetal": map[string]interface{}{
+							"baseDnsDomain": "example.com",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed Baremetal multi-node without VIPs",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":         "test",
+						"cloud":        "baremetal",
+						"sshPublicKey": "ssh-rsa key",
+						"baremetal": map[string]interface{}{
+							"baseDnsDomain": "example.com",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Baremetal single node with workers",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":         "test",
+						"cloud":        "baremetal",
+						"sshPublicKey": "ssh-rsa key",
+						"master":       map[string]interface{}{"replicas": 1},
+						"worker":       map[string]interface{}{"replicas": 2},
+						"baremetal": map[string]interface{}{
+							"baseDnsDomain": "example.com",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Baremetal without baseDnsDomain",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":         "test",
+						"cloud":        "baremetal",
+						"sshPublicKey": "ssh-rsa key",
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Failed, bad valuesPath",
 			fields: fields{
-				valuesPath: "bad-values-path.yaml",
+				valuesFlags: helpers.ValuesFlags{Paths: []string{"bad-values-path.yaml"}},
 			},
 			wantErr: true,
 		},
@@ -197,6 +286,48 @@ func TestOptions_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Success SNO on AWS",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":     "test",
+						"cloud":    "aws",
+						"topology": "sno",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed SNO on VSphere",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":     "test",
+						"cloud":    "vsphere",
+						"topology": "sno",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed standard topology without worker",
+			fields: fields{
+				values: map[string]interface{}{
+					"managedCluster": map[string]interface{}{
+						"name":     "test",
+						"cloud":    "aws",
+						"topology": "standard",
+						"worker": map[string]interface{}{
+							"replicas": 0,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Success replace clusterName",
 			fields: fields{
@@ -217,7 +348,7 @@ func TestOptions_validate(t *testing.T) {
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
 				cloud:       tt.fields.cloud,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 				outputFile:  tt.fields.outputFile,
 			}
@@ -243,6 +374,93 @@ func TestOptions_validate(t *testing.T) {
 	}
 }
 
+func TestOptions_applyHub_topology(t *testing.T) {
+	tests := []struct {
+		topology        string
+		wantMasters     int
+		wantWorkers     int
+		wantMachinePool bool
+	}{
+		{topology: "sno", wantMasters: 1, wantWorkers: 0, wantMachinePool: false},
+		{topology: "compact", wantMasters: 3, wantWorkers: 0, wantMachinePool: false},
+		{topology: "standard", wantMasters: 3, wantWorkers: 3, wantMachinePool: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topology, func(t *testing.T) {
+			values := readValues(t, "values-fake-aws.yaml")
+			if err := helpers.SetNestedField(values, tt.topology, "managedCluster.topology"); err != nil {
+				t.Fatal(err)
+			}
+			resources := applyHubDryRun(t, values)
+			if _, ok := resources["MachinePool"]; ok != tt.wantMachinePool {
+				t.Errorf("expected MachinePool rendered %t, got %t", tt.wantMachinePool, ok)
+			}
+			if masters, _ := topology.Replicas(values, "installConfig.controlPlane.replicas", -1); masters != tt.wantMasters {
+				t.Errorf("expected %d masters in the install-config, got %d", tt.wantMasters, masters)
+			}
+			compute, _, _ := unstructured.NestedSlice(values, "installConfig", "compute")
+			if len(compute) != 1 {
+				t.Fatalf("expected one compute pool, got %v", compute)
+			}
+			if workers, _ := topology.Replicas(compute[0].(map[string]interface{}), "replicas", -1); workers != tt.wantWorkers {
+				t.Errorf("expected %d workers in the install-config, got %d", tt.wantWorkers, workers)
+			}
+		})
+	}
+}
+
+func TestOptions_validate_interactive(t *testing.T) {
+	dir := t.TempDir()
+	valuesPath := filepath.Join(dir, "values.yaml")
+	t.Setenv("CM_TEST_SSH_KEY", "secret")
+
+	// the values are validated before being written and confirmed
+	o := &Options{
+		CMFlags:     genericclioptionscm.NewCMFlags(nil),
+		interactive: true,
+		values: map[string]interface{}{
+			"managedCluster": map[string]interface{}{
+				"name":   "test",
+				"cloud":  "aws",
+				"regoin": "us-east-1",
+			},
+		},
+		wizard: helpers.NewWizard(strings.NewReader(valuesPath+"\ny\n"), &bytes.Buffer{}, nil),
+	}
+	if err := o.validate(); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if _, err := os.Stat(valuesPath); !os.IsNotExist(err) {
+		t.Errorf("the invalid values must not be written, got %v", err)
+	}
+
+	// the written values keep the references
+	o.rawValues = map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name":          "test",
+			"cloud":         "aws",
+			"sshPrivateKey": "${env:CM_TEST_SSH_KEY}",
+		},
+	}
+	o.values = helpers.CopyValues(o.rawValues)
+	if err := helpers.ResolveReferences(o.values); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.validate(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(valuesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "${env:CM_TEST_SSH_KEY}") || strings.Contains(string(b), "secret\n") {
+		t.Errorf("the values must be written with their references, got %q", string(b))
+	}
+	if o.skipRun {
+		t.Error("the run must not be skipped when confirmed")
+	}
+}
+
 func TestOptions_runWithClient(t *testing.T) {
 	testEnv := &envtest.Environment{
 		CRDDirectoryPaths: []string{
@@ -289,7 +507,7 @@ func TestOptions_runWithClient(t *testing.T) {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
 		cloud       string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 		outputFile  string
 	}
@@ -326,7 +544,7 @@ func TestOptions_runWithClient(t *testing.T) {
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
 				cloud:       tt.fields.cloud,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 				outputFile:  tt.fields.outputFile,
 			}
@@ -372,7 +590,7 @@ func TestOptions_runWithClient(t *testing.T) {
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
 				cloud:       tt.fields.cloud,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 				outputFile:  tt.fields.outputFile,
 			}
@@ -0,0 +1,83 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"os"
+
+	"github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	"github.com/stolostron/cm-cli/pkg/credentials"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+)
+
+// interact asks the values missing from the values files, the values are written and confirmed once validated, the clusterimagesets and credentials are listed from the hub
+func (o *Options) interact() error {
+	kubeClient, err := o.CMFlags.KubectlFactory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := o.CMFlags.KubectlFactory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	namespace, _, err := o.CMFlags.KubectlFactory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	schema, err := helpers.LoadSchema(scenario.GetScenarioResourcesReader(), valuesSchemaPath)
+	if err != nil {
+		return err
+	}
+	if len(o.clusterName) != 0 {
+		if err := helpers.SetNestedField(o.values, o.clusterName, "managedCluster.name"); err != nil {
+			return err
+		}
+	}
+	w := helpers.NewWizard(os.Stdin, o.streams.Out, map[string]helpers.ChoicesFunc{
+		"clusterimagesets": helpers.ClusterImageSetChoices(dynamicClient),
+		"credentials":      credentials.Choices(kubeClient, namespace, "managedCluster.cloud"),
+	})
+	if err := w.Run(schema, o.values); err != nil {
+		return err
+	}
+	o.wizard = w
+	// the values are written with their references, they are resolved for the validation and the run
+	o.rawValues = helpers.CopyValues(o.values)
+	return helpers.ResolveReferences(o.values)
+}
+
+// confirm writes the values validated by validate and asks whether to create the cluster
+func (o *Options) confirm() error {
+	if err := o.checkCredentials(); err != nil {
+		return err
+	}
+	if err := o.wizard.WriteValues(o.rawValues); err != nil {
+		return err
+	}
+	create, err := o.wizard.Confirm("Create the cluster now", true)
+	if err != nil {
+		return err
+	}
+	o.skipRun = !create
+	return nil
+}
+
+// checkCredentials checks the credentials secret exists and matches the cloud
+func (o *Options) checkCredentials() error {
+	if len(o.credentials) == 0 {
+		return nil
+	}
+	kubeClient, err := o.CMFlags.KubectlFactory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	defaultNamespace, _, err := o.CMFlags.KubectlFactory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	namespace, name := credentials.ParseRef(o.credentials, defaultNamespace)
+	secret, err := credentials.Get(kubeClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	return credentials.SetValues(secret, o.cloud, "managedCluster", helpers.CopyValues(o.values))
+}
@@ -5,4 +5,13 @@
     {{- $release = (print $release "-" .managedCluster.name ) }}
 {{- $release }}
   {{- end }}
+{{- end }}
+{{- define "agentLabels" }}
+  {{- if .managedCluster.baremetal.agentLabels }}
+    {{- range $key, $value := .managedCluster.baremetal.agentLabels }}
+{{ $key }}: {{ $value | quote }}
+    {{- end }}
+  {{- else }}
+cluster-name: {{ .managedCluster.name | quote }}
+  {{- end }}
 {{- end }}
\ No newline at end of file
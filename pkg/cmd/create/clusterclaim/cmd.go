@@ -20,6 +20,12 @@ var example = `
 
 # Create clusterclaims on a given clusterpoolhost
 %[1]s create cc <clusterpool> <clusterclaim_name>[,<clusterclaim_name>...] --cph <clusterpoolhost> <options>
+
+# Create clusterclaims from the first clusterpool having ready or standby clusters
+%[1]s create cc <clusterclaim_name>[,<clusterclaim_name>...] --pools <clusterpool>,<clusterpool>... <options>
+
+# Create clusterclaims from the first clusterpool, sorted by name, matching a label selector and having ready or standby clusters
+%[1]s create cc <clusterclaim_name>[,<clusterclaim_name>...] --pool-selector <label>=<value> <options>
 `
 
 // NewCmd ...
@@ -51,6 +57,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
 	cmd.Flags().BoolVar(&o.WithCredentials, "creds", o.WithCredentials, "If set the credentials will be displayed")
 	cmd.Flags().BoolVar(&o.Import, "import", false, "If set the clusterclaim will be imported")
+	cmd.Flags().StringSliceVar(&o.ClusterPools, "pools", []string{}, "The candidate clusterpools in order of preference, the first one with ready or standby clusters is used")
+	cmd.Flags().StringVar(&o.ClusterPoolSelector, "pool-selector", "", "The label selector of the candidate clusterpools, the first one by name with ready or standby clusters is used")
+	cmd.Flags().StringVar(&o.Metadata.Description, "description", "", "The purpose of the clusterclaims, recorded as annotation")
+	cmd.Flags().StringVar(&o.Metadata.Ticket, "ticket", "", "The ticket for which the clusterclaims are created, recorded as annotation")
 
 	return cmd
 }
@@ -12,6 +12,16 @@ import (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(o.ClusterPools) != 0 || len(o.ClusterPoolSelector) != 0 {
+		if len(args) < 1 {
+			return fmt.Errorf("clusterclaim name is missing")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("the clusterpool can not be set with pools or pool-selector")
+		}
+		o.ClusterClaims = args[0]
+		return nil
+	}
 	if len(args) < 1 {
 		return fmt.Errorf("clusterpool name is missing")
 	}
@@ -24,6 +34,9 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 }
 
 func (o *Options) validate(cmd *cobra.Command) error {
+	if len(o.ClusterPools) != 0 && len(o.ClusterPoolSelector) != 0 {
+		return fmt.Errorf("pools and pool-selector are mutually exclusive")
+	}
 	if o.Import {
 		rhacmConstraint := ">=2.4.0"
 		supported, platform, err := helpers.IsSupportedVersion(o.CMFlags, true, o.ClusterPoolHost, rhacmConstraint, "")
@@ -48,7 +61,17 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
-	err = cph.CreateClusterClaims(o.ClusterClaims, o.ClusterPool, o.Import, o.Timeout, o.CMFlags.DryRun, o.outputFile, o.GetOptions.PrintFlags)
+	if len(o.ClusterPool) == 0 {
+		o.ClusterPool, err = cph.SelectClusterPool(o.ClusterPools,
+			o.ClusterPoolSelector,
+			len(strings.Split(o.ClusterClaims, ",")),
+			o.CMFlags.DryRun)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cph.CreateClusterClaims(o.ClusterClaims, o.ClusterPool, o.Import, o.Metadata, o.Timeout, o.CMFlags.DryRun, o.outputFile, o.GetOptions.PrintFlags)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@
 package clusterclaim
 
 import (
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/kubectl/pkg/cmd/get"
@@ -11,10 +12,16 @@ type Options struct {
 	//CMFlags: The generic optiosn from the cm cli-runtime.
 	CMFlags *genericclioptionscm.CMFlags
 	//The list of cluster claim name to create (comma-separated)
-	ClusterClaims   string
-	ClusterPool     string
-	ClusterPoolHost string
-	Import          bool
+	ClusterClaims string
+	ClusterPool   string
+	//The candidate clusterpools
+	ClusterPools []string
+	//The label selector of the candidate clusterpools
+	ClusterPoolSelector string
+	ClusterPoolHost     string
+	Import              bool
+	//The description and ticket recorded on the clusterclaims
+	Metadata        *clusterpoolhost.ClusterClaimMetadata
 	GetOptions      *get.GetOptions
 	WithCredentials bool
 	Timeout         int
@@ -26,5 +33,6 @@ func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.
 	return &Options{
 		CMFlags:    cmFlags,
 		GetOptions: get.NewGetOptions("cm", streams),
+		Metadata:   &clusterpoolhost.ClusterClaimMetadata{},
 	}
 }
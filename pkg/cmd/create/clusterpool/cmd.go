@@ -23,6 +23,7 @@ const (
 )
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "common/values-template.yaml")
+var valuesSchemaPath = filepath.Join(scenarioDirectory, "common", helpers.ValuesSchemaFileName)
 
 var example = `
 # Create a clusterpool
@@ -30,6 +31,9 @@ var example = `
 
 # Create a cluster with cluster name overwrite by args
 %[1]s create cp [<clusterpool_name>] --values values.yaml [--cph <clusterpoolhost_name>]
+
+# Create a clusterpool using the keys of credentials created with '%[1]s create credentials --cph'
+%[1]s create cp [<clusterpool_name>] --values values.yaml --credentials <credentials_name> [--cph <clusterpoolhost_name>]
 `
 
 // NewCmd ...
@@ -54,8 +58,12 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 
 	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
 	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
-	cmd.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.profile, "profile", "", "The profile, saved with 'profile save', layered beneath the values files and the --set options")
+	cmd.Flags().StringVar(&o.credentials, "credentials", "", "The [<namespace>/]<name> of the credentials on the clusterpoolhost to use instead of the keys in the values, the namespace defaults to the clusterpoolhost one")
+	cmd.Flags().BoolVar(&o.interactive, "interactive", false, "Ask the values missing from the values files field by field, then create or write them to a file")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	o.exportFlags.AddFlags(cmd.Flags())
 	cmd.Flags().StringVar(&o.clusterSetName, "cluster-set", "", "The clusterset to which the clusterpool should be place")
 	return cmd
 }
@@ -5,7 +5,12 @@ import (
 	"fmt"
 
 	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost/scenario"
+	"github.com/stolostron/cm-cli/pkg/credentials"
 	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/profile"
+	"github.com/stolostron/cm-cli/pkg/topology"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/spf13/cobra"
 )
@@ -17,23 +22,60 @@ const (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+	if o.exportFlags.IsSet() {
+		o.CMFlags.DryRun = true
+	}
+	// the --interactive mode keeps the references to write them in the values file
+	o.valuesFlags.KeepReferences = o.interactive
+	if len(o.profile) != 0 {
+		if o.interactive {
+			p, err := profile.Get(o.profile)
+			if err != nil {
+				return err
+			}
+			o.valuesFlags.Base = p.Values
+		} else {
+			o.valuesFlags.Base, err = profile.GetValues(o.profile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	o.valuesFlags.NoStdin = o.interactive
+	o.values, err = o.valuesFlags.ToValuesMap()
 	if err != nil {
 		return err
 	}
 
-	if len(o.values) == 0 {
-		return fmt.Errorf("values are missing")
-	}
-
 	if len(args) > 0 {
 		o.ClusterPool = args[0]
 	}
 
+	if o.interactive {
+		if err := o.interact(); err != nil {
+			return err
+		}
+	}
+
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+
 	return nil
 }
 
 func (o *Options) validate() (err error) {
+	if err := o.exportFlags.Validate(); err != nil {
+		return err
+	}
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+
+	if len(o.credentials) == 0 {
+		o.credentials = credentials.RefFromValues(o.values)
+	}
+
 	icp, ok := o.values["clusterPool"]
 	if !ok || icp == nil {
 		return fmt.Errorf("clusterPool is missing")
@@ -49,6 +91,10 @@ func (o *Options) validate() (err error) {
 	}
 	o.cloud = cloud
 
+	if _, err := topology.Apply(o.values, "clusterPool", o.cloud); err != nil {
+		return err
+	}
+
 	_, ocpImageOk := cp["ocpImage"]
 	_, imageSetRef := cp["imageSetRef"]
 	if ocpImageOk && imageSetRef {
@@ -76,14 +122,40 @@ func (o *Options) validate() (err error) {
 
 	cp["clusterSetName"] = o.clusterSetName
 
+	if o.interactive {
+		return o.confirm()
+	}
+
 	return nil
 }
 
 func (o *Options) run() (err error) {
+	if o.skipRun {
+		return nil
+	}
 	cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(o.ClusterPoolHost)
 	if err != nil {
 		return err
 	}
 
-	return cph.CreateClusterPool(o.ClusterPool, o.cloud, o.values, o.CMFlags.DryRun, o.outputFile)
+	if len(o.credentials) != 0 {
+		restConfig, err := cph.GetGlobalRestConfig()
+		if err != nil {
+			return err
+		}
+		kubeClient, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return err
+		}
+		namespace, name := credentials.ParseRef(o.credentials, cph.Namespace)
+		secret, err := credentials.Get(kubeClient, namespace, name)
+		if err != nil {
+			return err
+		}
+		if err := credentials.SetValues(secret, o.cloud, "clusterPool", o.values); err != nil {
+			return err
+		}
+	}
+
+	return cph.CreateClusterPool(o.ClusterPool, o.cloud, o.values, o.CMFlags, o.outputFile, &o.exportFlags)
 }
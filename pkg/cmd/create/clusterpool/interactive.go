@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterpool
+
+import (
+	"os"
+
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost/scenario"
+	"github.com/stolostron/cm-cli/pkg/credentials"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// interact asks the values missing from the values files, the values are written and confirmed once validated, the clusterimagesets and credentials are listed from the clusterpoolhost
+func (o *Options) interact() error {
+	cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(o.ClusterPoolHost)
+	if err != nil {
+		return err
+	}
+	restConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	schema, err := helpers.LoadSchema(scenario.GetScenarioResourcesReader(), valuesSchemaPath)
+	if err != nil {
+		return err
+	}
+	if len(o.ClusterPool) != 0 {
+		if err := helpers.SetNestedField(o.values, o.ClusterPool, "clusterPool.name"); err != nil {
+			return err
+		}
+	}
+	w := helpers.NewWizard(os.Stdin, o.streams.Out, map[string]helpers.ChoicesFunc{
+		"clusterimagesets": helpers.ClusterImageSetChoices(dynamicClient),
+		"credentials":      credentials.Choices(kubeClient, cph.Namespace, "clusterPool.cloud"),
+	})
+	if err := w.Run(schema, o.values); err != nil {
+		return err
+	}
+	o.wizard = w
+	// the values are written with their references, they are resolved for the validation and the run
+	o.rawValues = helpers.CopyValues(o.values)
+	return helpers.ResolveReferences(o.values)
+}
+
+// confirm writes the values validated by validate and asks whether to create the clusterpool
+func (o *Options) confirm() error {
+	if err := o.checkCredentials(); err != nil {
+		return err
+	}
+	if err := o.wizard.WriteValues(o.rawValues); err != nil {
+		return err
+	}
+	create, err := o.wizard.Confirm("Create the clusterpool now", true)
+	if err != nil {
+		return err
+	}
+	o.skipRun = !create
+	return nil
+}
+
+// checkCredentials checks the credentials secret exists on the clusterpoolhost and matches the cloud
+func (o *Options) checkCredentials() error {
+	if len(o.credentials) == 0 {
+		return nil
+	}
+	cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(o.ClusterPoolHost)
+	if err != nil {
+		return err
+	}
+	restConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	namespace, name := credentials.ParseRef(o.credentials, cph.Namespace)
+	secret, err := credentials.Get(kubeClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	return credentials.SetValues(secret, o.cloud, "clusterPool", helpers.CopyValues(o.values))
+}
@@ -3,6 +3,7 @@ package clusterpool
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -13,14 +14,29 @@ type Options struct {
 	ClusterPoolHost string
 	cloud           string
 	clusterSetName  string
-	valuesPath      string
+	valuesFlags     helpers.ValuesFlags
 	values          map[string]interface{}
+	//The [<namespace>/]<name> of the credentials secret to use instead of the keys in the values
+	credentials string
+	//The profile layered beneath the values files and flags
+	profile string
+	//Ask the values field by field
+	interactive bool
+	//Set when the user chooses in the --interactive mode to only write the values
+	skipRun bool
+	//The wizard of the --interactive mode and the values with their ${...} references it writes
+	wizard    *helpers.Wizard
+	rawValues map[string]interface{}
+	streams   genericclioptions.IOStreams
 	//The file to output the resources will be sent to the file.
 	outputFile string
+	//The directory to export the resources for GitOps instead of applying them
+	exportFlags helpers.ExportFlags
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
 	return &Options{
 		CMFlags: cmFlags,
+		streams: streams,
 	}
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/stolostron/cm-cli/pkg/cmd/create/clusterclaim"
 	"github.com/stolostron/cm-cli/pkg/cmd/create/clusterpool"
 	"github.com/stolostron/cm-cli/pkg/cmd/create/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/cmd/create/credentials"
 	"github.com/stolostron/cm-cli/pkg/cmd/create/hypershiftdeployment"
+	"github.com/stolostron/cm-cli/pkg/cmd/create/machinepool"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	clusteradmclusterset "open-cluster-management.io/clusteradm/pkg/cmd/create/clusterset"
 	clusteradmwork "open-cluster-management.io/clusteradm/pkg/cmd/create/work"
@@ -29,9 +31,11 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, cmFlag
 	cmd.AddCommand(clusterpoolhost.NewCmd(cmFlags, streams))
 	cmd.AddCommand(clusterclaim.NewCmd(cmFlags, streams))
 	cmd.AddCommand(clusterpool.NewCmd(cmFlags, streams))
+	cmd.AddCommand(credentials.NewCmd(cmFlags, streams))
 	cmd.AddCommand(clusteradmclusterset.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(clusteradmwork.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(hypershiftdeployment.NewCmd(cmFlags, streams))
+	cmd.AddCommand(machinepool.NewCmd(cmFlags, streams))
 
 	return cmd
 }
@@ -0,0 +1,61 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/stolostron/cm-cli/pkg/credentials"
+	"github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var valuesTemplatePath = filepath.Join("create", "values-template.yaml")
+var valuesSchemaPath = filepath.Join("create", helpers.ValuesSchemaFileName)
+
+var example = `
+# Create the credentials of a cloud provider in the current namespace of the hub
+%[1]s create credentials <credentials_name> --cloud aws --values values.yaml
+
+# Create the credentials of a cloud provider on a clusterpoolhost to use with '%[1]s create cp --credentials'
+%[1]s create credentials <credentials_name> --cloud gcp --values values.yaml --cph <clusterpoolhost_name>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:     "credentials",
+		Aliases: []string{"cred", "creds"},
+		Short:   "Create the credentials of a cloud provider",
+		Long: fmt.Sprintf("Create a secret labelled %s and %s=<cloud> holding the credentials of a cloud provider",
+			credentials.CredentialsLabel, credentials.TypeLabel),
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
+	cmd.Flags().StringVar(&o.cloud, "cloud", "", fmt.Sprintf("The cloud provider (%s)", strings.Join(credentials.Clouds, ", ")))
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "Create the credentials in the namespace of this clusterpoolhost instead of the hub")
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stolostron/cm-cli/pkg/credentials"
+	"github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.values, err = o.valuesFlags.ToValuesMap()
+	if err != nil {
+		return err
+	}
+
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+
+	if len(args) > 0 {
+		o.Credentials = args[0]
+	}
+
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+
+	ic, ok := o.values["credentials"]
+	if !ok || ic == nil {
+		return fmt.Errorf("credentials is missing")
+	}
+	c, ok := ic.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("credentials is not a map")
+	}
+
+	if o.cloud == "" {
+		o.cloud, _ = helpers.NestedString(o.values, "credentials.cloud")
+	}
+	if !credentials.IsSupported(o.cloud) {
+		return fmt.Errorf("supported cloud type are (%s) and got %s", strings.Join(credentials.Clouds, ", "), o.cloud)
+	}
+	if ic, ok := c[o.cloud]; !ok || ic == nil {
+		return fmt.Errorf("credentials.%s is missing", o.cloud)
+	}
+	c["cloud"] = o.cloud
+
+	if o.Credentials == "" {
+		o.Credentials, _ = helpers.NestedString(o.values, "credentials.name")
+		if o.Credentials == "" {
+			return fmt.Errorf("credentials name is missing")
+		}
+	}
+	c["name"] = o.Credentials
+
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	target, err := credentials.NewTarget(o.CMFlags, o.ClusterPoolHost)
+	if err != nil {
+		return err
+	}
+	if err = helpers.SetNestedField(o.values, target.Namespace, "credentials.namespace"); err != nil {
+		return err
+	}
+	return target.Apply(o.values, o.CMFlags, o.outputFile)
+}
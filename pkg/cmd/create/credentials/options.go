@@ -0,0 +1,26 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags         *genericclioptionscm.CMFlags
+	Credentials     string
+	ClusterPoolHost string
+	cloud           string
+	valuesFlags     helpers.ValuesFlags
+	values          map[string]interface{}
+	//The file to output the resources will be sent to the file.
+	outputFile string
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+	}
+}
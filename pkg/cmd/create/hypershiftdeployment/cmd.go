@@ -23,6 +23,7 @@ const (
 )
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
+var valuesSchemaPath = filepath.Join(scenarioDirectory, helpers.ValuesSchemaFileName)
 
 var example = `
 # Create a hypershiftdeployment
@@ -73,8 +74,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 
 	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
 	cmd.Flags().StringVarP(&o.clusterNamespace, "namespace", "n", "", "Name of the cluster")
-	cmd.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.profile, "profile", "", "The profile, saved with 'profile save', layered beneath the values files and the --set options")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cmd.Flags())
 
 	return cmd
 }
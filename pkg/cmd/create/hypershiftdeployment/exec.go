@@ -7,6 +7,7 @@ import (
 	"github.com/stolostron/applier/pkg/apply"
 	"github.com/stolostron/cm-cli/pkg/cmd/create/hypershiftdeployment/scenario"
 	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/profile"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/rest"
 
@@ -27,7 +28,13 @@ const (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
-	o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+	if len(o.profile) != 0 {
+		o.valuesFlags.Base, err = profile.GetValues(o.profile)
+		if err != nil {
+			return err
+		}
+	}
+	o.values, err = o.valuesFlags.ToValuesMap()
 	if err != nil {
 		return err
 	}
@@ -44,6 +51,10 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 }
 
 func (o *Options) validate() (err error) {
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+
 	ok, err := helpers.NestedExists(o.values, "managedCluster")
 	if err != nil {
 		return err
@@ -193,5 +204,5 @@ func (o *Options) runWithClient(restConfig *rest.Config) (err error) {
 	}
 	output = append(output, out...)
 
-	return apply.WriteOutput(o.outputFile, output)
+	return helpers.WriteOutput(o.CMFlags, applierBuilder, o.outputFile, output)
 }
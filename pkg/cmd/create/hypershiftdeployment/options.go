@@ -3,6 +3,7 @@ package hypershiftdeployment
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -16,8 +17,10 @@ type Options struct {
 	cloudProviderSecretName string
 	region                  string
 	location                string
-	valuesPath              string
+	valuesFlags             helpers.ValuesFlags
 	values                  map[string]interface{}
+	//The profile layered beneath the values files and flags
+	profile string
 	//The file to output the resources will be sent to the file.
 	outputFile string
 }
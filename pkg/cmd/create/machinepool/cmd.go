@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	"fmt"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Create a machinepool on a hub cluster
+%[1]s create machinepool <machinepool_name> --cluster <cluster_name> --instance-type m5.2xlarge --replicas 2
+
+# Create an autoscaled and tainted machinepool on a clusterclaim of the active clusterpoolhost
+%[1]s create machinepool <machinepool_name> --cluster <clusterclaim_name> --clusterclaim --min 1 --max 4 --labels node-role.kubernetes.io/infra= --taints node-role.kubernetes.io/infra:NoSchedule
+
+# Create a machinepool in given zones on a clusterclaim of a given clusterpoolhost
+%[1]s create machinepool <machinepool_name> --cluster <clusterclaim_name> --cph <clusterpoolhost_name> --zones us-east-1a,us-east-1b
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "machinepool",
+		Aliases:      []string{"machinepools", "mp"},
+		Short:        "Create a machinepool",
+		Long:         "Create a hive machinepool, the platform is copied from the worker machinepool of the cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate(cmd))
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterName, "cluster", "", "Name of the cluster or of the clusterclaim")
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost of the clusterclaim, implies --clusterclaim")
+	cmd.Flags().BoolVar(&o.ClusterClaim, "clusterclaim", false, "The cluster is a clusterclaim of the active clusterpoolhost")
+	cmd.Flags().StringVar(&o.MachinePool.InstanceType, "instance-type", "", "The instance type (or the flavor on openstack), default to the one of the worker machinepool")
+	cmd.Flags().StringSliceVar(&o.MachinePool.Zones, "zones", nil, "The zones, default to the ones of the worker machinepool")
+	cmd.Flags().StringToStringVar(&o.MachinePool.Labels, "labels", nil, "The labels of the nodes (e.g. key1=value1,key2=value2)")
+	cmd.Flags().StringSliceVar(&o.taints, "taints", nil, "The taints of the nodes <key>[=<value>]:<effect> (e.g. key1=value1:NoSchedule)")
+	cmd.Flags().Int64Var(&o.MachinePool.Replicas, "replicas", 3, "The number of nodes")
+	cmd.Flags().Int32Var(&o.MachinePool.MinReplicas, "min", 0, "The minimum number of nodes, enables the autoscaling with --max")
+	cmd.Flags().Int32Var(&o.MachinePool.MaxReplicas, "max", 0, "The maximum number of nodes, enables the autoscaling")
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/machinepool"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("machinepool name is missing")
+	}
+	o.MachinePool.Name = args[0]
+	for _, t := range o.taints {
+		taint, err := machinepool.ParseTaint(t)
+		if err != nil {
+			return err
+		}
+		o.MachinePool.Taints = append(o.MachinePool.Taints, taint)
+	}
+	return nil
+}
+
+func (o *Options) validate(cmd *cobra.Command) error {
+	if len(o.ClusterName) == 0 {
+		return fmt.Errorf("cluster name is missing")
+	}
+	return validateScale(cmd, o.MachinePool.Replicas, o.MachinePool.MinReplicas, o.MachinePool.MaxReplicas)
+}
+
+func (o *Options) run() (err error) {
+	target, err := machinepool.NewTarget(o.CMFlags, o.ClusterName, o.ClusterPoolHost, o.ClusterClaim)
+	if err != nil {
+		return err
+	}
+	return target.Create(&o.MachinePool, o.CMFlags.DryRun, o.outputFile)
+}
+
+func validateScale(cmd *cobra.Command, replicas int64, minReplicas, maxReplicas int32) error {
+	if cmd.Flags().Changed("min") || cmd.Flags().Changed("max") {
+		if cmd.Flags().Changed("replicas") {
+			return fmt.Errorf("--replicas and --min/--max are mutually exclusive")
+		}
+		return machinepool.ValidateAutoscaling(minReplicas, maxReplicas)
+	}
+	if replicas < 0 {
+		return fmt.Errorf("replicas must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/machinepool"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The cluster or the clusterclaim name
+	ClusterName     string
+	ClusterPoolHost string
+	//true if the cluster is a clusterclaim
+	ClusterClaim bool
+	MachinePool  machinepool.MachinePool
+	//The taints in the <key>[=<value>]:<effect> format
+	taints []string
+	//The file to output the resources will be sent to the file.
+	outputFile string
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 
@@ -19,7 +18,7 @@ import (
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	//Check if default values must be used
-	if o.valuesPath == "" {
+	if !o.valuesFlags.HasFiles() {
 		if len(args) > 0 {
 			o.clusterName = args[0]
 		}
@@ -32,11 +31,14 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 		o.values["managedCluster"] = mc
 	} else {
 		//Read values
-		o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+		o.values, err = o.valuesFlags.ReadFiles()
 		if err != nil {
 			return err
 		}
 	}
+	if err = o.valuesFlags.ApplyOverrides(o.values); err != nil {
+		return err
+	}
 
 	if len(o.values) == 0 {
 		return fmt.Errorf("values are missing")
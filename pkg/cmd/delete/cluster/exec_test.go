@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/client-go/dynamic"
 	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/kubectl/pkg/scheme"
@@ -23,7 +24,7 @@ func TestOptions_complete(t *testing.T) {
 	type fields struct {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 	}
 	type args struct {
@@ -39,14 +40,14 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Failed, bad valuesPath",
 			fields: fields{
-				valuesPath: "bad-values-path.yaml",
+				valuesFlags: helpers.ValuesFlags{Paths: []string{"bad-values-path.yaml"}},
 			},
 			wantErr: true,
 		},
 		{
 			name: "Failed, empty values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-empty.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-empty.yaml")}},
 			},
 			wantErr: true,
 		},
@@ -65,7 +66,7 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Success, with values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-fake.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-fake.yaml")}},
 			},
 			wantErr: false,
 		},
@@ -75,7 +76,7 @@ func TestOptions_complete(t *testing.T) {
 			o := &Options{
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 			}
 			if err := o.complete(tt.args.cmd, tt.args.args); (err != nil) != tt.wantErr {
@@ -89,7 +90,7 @@ func TestOptions_validate(t *testing.T) {
 	type fields struct {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 	}
 	tests := []struct {
@@ -154,7 +155,7 @@ func TestOptions_validate(t *testing.T) {
 			o := &Options{
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 			}
 			if err := o.validate(); (err != nil) != tt.wantErr {
@@ -177,7 +178,7 @@ func TestOptions_runWithClient(t *testing.T) {
 	type fields struct {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 	}
 	type args struct {
@@ -220,7 +221,7 @@ func TestOptions_runWithClient(t *testing.T) {
 			o := &Options{
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 			}
 			if err := o.runWithClient(tt.args.clusterClient, tt.args.dynamicClient); (err != nil) != tt.wantErr {
@@ -43,6 +43,7 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	}
 
 	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Delete the clusterclaims even if they are checked out by another user")
 
 	return cmd
 }
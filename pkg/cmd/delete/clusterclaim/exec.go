@@ -27,6 +27,6 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
-	return cph.DeleteClusterClaims(o.ClusterClaims, o.CMFlags.DryRun)
+	return cph.DeleteClusterClaims(o.ClusterClaims, o.Force, o.CMFlags.DryRun)
 
 }
@@ -12,6 +12,8 @@ type Options struct {
 	//The list of cluster claims to delete (comma-separated)
 	ClusterClaims   string
 	ClusterPoolHost string
+	//Force the operation even if the clusterclaims are checked out by another user
+	Force bool
 	//The file to output the resources will be sent to the file.
 	outputFile string
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stolostron/cm-cli/pkg/cmd/delete/clusterpool"
 	"github.com/stolostron/cm-cli/pkg/cmd/delete/clusterpoolhost"
 	"github.com/stolostron/cm-cli/pkg/cmd/delete/hypershiftdeployment"
+	"github.com/stolostron/cm-cli/pkg/cmd/delete/machinepool"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	clusteradmclusterset "open-cluster-management.io/clusteradm/pkg/cmd/delete/clusterset"
 	clusteradmclusterwork "open-cluster-management.io/clusteradm/pkg/cmd/delete/work"
@@ -30,5 +31,6 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, cmFlag
 	cmd.AddCommand(clusteradmclusterset.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(clusteradmclusterwork.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(hypershiftdeployment.NewCmd(cmFlags, streams))
+	cmd.AddCommand(machinepool.NewCmd(cmFlags, streams))
 	return cmd
 }
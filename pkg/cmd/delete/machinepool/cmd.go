@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	"fmt"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Delete a machinepool of a hub cluster
+%[1]s delete machinepool <machinepool_name> --cluster <cluster_name>
+
+# Delete a machinepool of a clusterclaim on a given clusterpoolhost
+%[1]s delete machinepool <machinepool_name> --cluster <clusterclaim_name> --cph <clusterpoolhost_name>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "machinepool",
+		Aliases:      []string{"machinepools", "mp"},
+		Short:        "Delete a machinepool",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterName, "cluster", "", "Name of the cluster or of the clusterclaim")
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost of the clusterclaim, implies --clusterclaim")
+	cmd.Flags().BoolVar(&o.ClusterClaim, "clusterclaim", false, "The cluster is a clusterclaim of the active clusterpoolhost")
+
+	return cmd
+}
@@ -0,0 +1,32 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/machinepool"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("machinepool name is missing")
+	}
+	o.MachinePool = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.ClusterName) == 0 {
+		return fmt.Errorf("cluster name is missing")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	target, err := machinepool.NewTarget(o.CMFlags, o.ClusterName, o.ClusterPoolHost, o.ClusterClaim)
+	if err != nil {
+		return err
+	}
+	return target.Delete(o.MachinePool, o.CMFlags.DryRun)
+}
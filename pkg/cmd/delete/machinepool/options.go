@@ -0,0 +1,25 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The machinepool name
+	MachinePool string
+	//The cluster or the clusterclaim name
+	ClusterName     string
+	ClusterPoolHost string
+	//true if the cluster is a clusterclaim
+	ClusterClaim bool
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+	}
+}
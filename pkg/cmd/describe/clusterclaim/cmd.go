@@ -0,0 +1,43 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Describe a clusterclaim
+%[1]s describe cc <clusterclaim_name>
+
+# Describe a clusterclaim on a given clusterpoolhost
+%[1]s describe cc <clusterclaim_name> --cph <clusterpoolhost>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "clusterclaim",
+		Aliases:      []string{"clusterclaims", "cc", "ccs"},
+		Short:        "describe a clusterclaim, its clusterdeployment, provision attempts and events",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().BoolVar(&o.ShowEvents, "show-events", true, "If true, display events related to the clusterclaim and its clusterdeployment")
+
+	return cmd
+}
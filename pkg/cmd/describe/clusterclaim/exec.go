@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("clusterclaim name is missing")
+	}
+	o.ClusterClaim = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(o.ClusterPoolHost)
+	if err != nil {
+		return err
+	}
+
+	return cph.DescribeClusterClaim(o.ClusterClaim, o.ShowEvents, o.streams.Out)
+}
@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags         *genericclioptionscm.CMFlags
+	ClusterClaim    string
+	ClusterPoolHost string
+	//If true the events are displayed
+	ShowEvents bool
+	streams    genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+	}
+}
@@ -75,7 +75,7 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 
 	cluster.SetUsageTemplate(clusteradmhelpers.UsageTempate(cluster, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
 	cluster.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the cluster")
-	cluster.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cluster.Flags())
 
 	return cluster
 }
@@ -10,12 +10,11 @@ import (
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
 
 	"github.com/spf13/cobra"
-	"github.com/stolostron/cm-cli/pkg/helpers"
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	//Check if default values must be used
-	if o.valuesPath == "" {
+	if !o.valuesFlags.HasFiles() {
 		if len(args) > 0 {
 			o.clusterName = args[0]
 		}
@@ -28,11 +27,14 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 		o.values["managedCluster"] = mc
 	} else {
 		//Read values
-		o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+		o.values, err = o.valuesFlags.ReadFiles()
 		if err != nil {
 			return err
 		}
 	}
+	if err = o.valuesFlags.ApplyOverrides(o.values); err != nil {
+		return err
+	}
 
 	if len(o.values) == 0 {
 		return fmt.Errorf("values are missing")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterclientsetfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
 	cluster "open-cluster-management.io/api/cluster/v1"
@@ -20,7 +21,7 @@ func TestOptions_complete(t *testing.T) {
 	type fields struct {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 	}
 	type args struct {
@@ -36,21 +37,21 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Failed, bad valuesPath",
 			fields: fields{
-				valuesPath: "badpath",
+				valuesFlags: helpers.ValuesFlags{Paths: []string{"badpath"}},
 			},
 			wantErr: true,
 		},
 		{
 			name: "Failed, empty values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-empty.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-empty.yaml")}},
 			},
 			wantErr: true,
 		},
 		{
 			name: "Sucess, with values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-with-data.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-with-data.yaml")}},
 			},
 			wantErr: false,
 		},
@@ -60,7 +61,7 @@ func TestOptions_complete(t *testing.T) {
 			o := &Options{
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 			}
 			if err := o.complete(tt.args.cmd, tt.args.args); (err != nil) != tt.wantErr {
@@ -74,7 +75,7 @@ func TestOptions_validate(t *testing.T) {
 	type fields struct {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 	}
 	tests := []struct {
@@ -117,7 +118,7 @@ func TestOptions_validate(t *testing.T) {
 			o := &Options{
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 			}
 			if err := o.validate(); (err != nil) != tt.wantErr {
@@ -138,7 +139,7 @@ func TestOptions_runWithClient(t *testing.T) {
 	type fields struct {
 		CMFlags     *genericclioptionscm.CMFlags
 		clusterName string
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		values      map[string]interface{}
 	}
 	type args struct {
@@ -178,7 +179,7 @@ func TestOptions_runWithClient(t *testing.T) {
 			o := &Options{
 				CMFlags:     tt.fields.CMFlags,
 				clusterName: tt.fields.clusterName,
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				values:      tt.fields.values,
 			}
 			if err := o.runWithClient(tt.args.clusterClient); (err != nil) != tt.wantErr {
@@ -3,6 +3,7 @@ package cluster
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -10,7 +11,7 @@ type Options struct {
 	//CMFlags: The generic optiosn from the cm cli-runtime.
 	CMFlags     *genericclioptionscm.CMFlags
 	clusterName string
-	valuesPath  string
+	valuesFlags helpers.ValuesFlags
 	values      map[string]interface{}
 }
 
@@ -30,6 +30,7 @@ const (
 
 var valuesTemplatePath = filepath.Join(scenarioDirectory, "values-template.yaml")
 var valuesDefaultPath = filepath.Join(scenarioDirectory, "values-default.yaml")
+var valuesSchemaPath = filepath.Join(scenarioDirectory, helpers.ValuesSchemaFileName)
 
 // NewCmd provides a cobra command wrapping NewCmdImportCluster
 func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
@@ -63,9 +64,11 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	}
 
 	cluster.SetUsageTemplate(clusteradmhelpers.UsageTempate(cluster, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
-	cluster.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cluster.Flags())
 	cluster.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the cluster")
 	cluster.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cluster.Flags())
+	o.exportFlags.AddFlags(cluster.Flags())
 
 	return cluster
 }
@@ -17,8 +17,11 @@ import (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if o.exportFlags.IsSet() {
+		o.CMFlags.DryRun = true
+	}
 	//Check if default values must be used
-	if o.valuesPath == "" {
+	if !o.valuesFlags.HasFiles() {
 		if len(args) > 0 {
 			o.clusterName = args[0]
 		}
@@ -34,11 +37,14 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 		mc["name"] = o.clusterName
 	} else {
 		//Read values
-		o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+		o.values, err = o.valuesFlags.ReadFiles()
 		if err != nil {
 			return err
 		}
 	}
+	if err = o.valuesFlags.ApplyOverrides(o.values); err != nil {
+		return err
+	}
 
 	imc, ok := o.values["managedCluster"]
 	if !ok || imc == nil {
@@ -49,6 +55,9 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 }
 
 func (o *Options) validate() error {
+	if err := o.exportFlags.Validate(); err != nil {
+		return err
+	}
 	kubeClient, err := o.CMFlags.KubectlFactory.KubernetesClientSet()
 	if err != nil {
 		return err
@@ -61,6 +70,10 @@ func (o *Options) validate() error {
 }
 
 func (o *Options) validateWithClient(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface) error {
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+
 	imc, ok := o.values["managedCluster"]
 	if !ok || imc == nil {
 		return fmt.Errorf("managedCluster is missing")
@@ -109,5 +122,8 @@ func (o *Options) runWithClient(
 		return err
 	}
 	output = append(output, out...)
-	return apply.WriteOutput(o.outputFile, output)
+	if err := o.exportFlags.Export(output); err != nil {
+		return err
+	}
+	return helpers.WriteOutput(o.CMFlags, applierBuilder, o.outputFile, output)
 }
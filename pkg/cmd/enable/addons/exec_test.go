@@ -27,7 +27,7 @@ var testDir = filepath.Join("test", "unit")
 
 func TestOptions_complete(t *testing.T) {
 	type fields struct {
-		valuesPath  string
+		valuesFlags helpers.ValuesFlags
 		clusterName string
 	}
 	type args struct {
@@ -43,14 +43,14 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Failed, bad valuesPath",
 			fields: fields{
-				valuesPath: "badpath",
+				valuesFlags: helpers.ValuesFlags{Paths: []string{"badpath"}},
 			},
 			wantErr: true,
 		},
 		{
 			name: "Failed, empty values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-empty.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-empty.yaml")}},
 			},
 			wantErr: true,
 		},
@@ -62,7 +62,7 @@ func TestOptions_complete(t *testing.T) {
 		{
 			name: "Success, not replacing values",
 			fields: fields{
-				valuesPath: filepath.Join(testDir, "values-with-data.yaml"),
+				valuesFlags: helpers.ValuesFlags{Paths: []string{filepath.Join(testDir, "values-with-data.yaml")}},
 			},
 			wantErr: false,
 		},
@@ -77,7 +77,7 @@ func TestOptions_complete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			o := &Options{
-				valuesPath:  tt.fields.valuesPath,
+				valuesFlags: tt.fields.valuesFlags,
 				clusterName: tt.fields.clusterName,
 			}
 			if err := o.complete(tt.args.cmd, tt.args.args); (err != nil) != tt.wantErr {
@@ -3,17 +3,20 @@ package addons
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
 type Options struct {
 	//CMFlags: The generic optiosn from the cm cli-runtime.
 	CMFlags     *genericclioptionscm.CMFlags
-	valuesPath  string
+	valuesFlags helpers.ValuesFlags
 	values      map[string]interface{}
 	clusterName string
 	//The file to output the resources will be sent to the file.
 	outputFile string
+	//The directory to export the resources for GitOps instead of applying them
+	exportFlags helpers.ExportFlags
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
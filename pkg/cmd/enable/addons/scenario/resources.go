@@ -4,7 +4,7 @@ package scenario
 import (
 	"embed"
 
-	"github.com/stolostron/applier/pkg/asset"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 )
 
 //go:embed addons
@@ -14,6 +14,6 @@ var files embed.FS
 //  "github.com/stolostron/cm-cli/pkg/cmd/attach/cluster/scenario"
 // as we don't want to duplicate yamls
 
-func GetScenarioResourcesReader() *asset.ScenarioResourcesReader {
-	return asset.NewScenarioResourcesReader(&files)
+func GetScenarioResourcesReader() *helpers.ScenarioReader {
+	return helpers.NewScenarioReader("enable-addons", &files)
 }
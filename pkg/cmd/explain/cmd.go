@@ -0,0 +1,43 @@
+// Copyright Contributors to the Open Cluster Management project
+package explain
+
+import (
+	"fmt"
+	"strings"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/valuesschemas"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Explain the top level fields of the 'create cluster' values
+%[1]s explain create-cluster
+
+# Explain a field of the 'create cluster' values
+%[1]s explain create-cluster managedCluster.aws.worker.rootVolume
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:   "explain <command> [<dotted_path>]",
+		Short: "Describe the fields of the values of a command",
+		Long: fmt.Sprintf("Describe the type, default, allowed values, description and overriding options of a field of the values of a command (%s), "+
+			"from the schema and the values template embedded in cm", strings.Join(valuesschemas.Names(), ", ")),
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	return cmd
+}
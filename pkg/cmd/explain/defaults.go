@@ -0,0 +1,190 @@
+// Copyright Contributors to the Open Cluster Management project
+package explain
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	"github.com/stolostron/cm-cli/pkg/valuesschemas"
+)
+
+// templateDefault is a value applied by the templates when a field is missing or empty
+type templateDefault struct {
+	value string
+	// dirs are the directories of the templates applying the value (ie: aws, common)
+	dirs []string
+}
+
+// templateDefaults returns the defaults applied by the templates of the scenario of the command,
+// by dotted path. Two forms are recognized:
+// {{ default <value> .<path> }} and {{ if .<path> }}{{ .<path> }}{{ else }}<value>{{ end }},
+// the condition of the latter can be a parent of the path.
+func templateDefaults(s valuesschemas.ValuesSchema) (map[string][]templateDefault, error) {
+	dir := filepath.Dir(s.Path)
+	if filepath.Base(dir) == "common" {
+		dir = filepath.Dir(dir)
+	}
+	names, err := s.Reader.AssetNames([]string{dir}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	defaults := make(map[string][]templateDefault)
+	for _, name := range names {
+		if filepath.Ext(name) != ".yaml" || filepath.Base(name) == "values-template.yaml" {
+			continue
+		}
+		b, err := s.Reader.Asset(name)
+		if err != nil {
+			return nil, err
+		}
+		t := parse.New(name)
+		t.Mode = parse.SkipFuncCheck
+		if _, err := t.Parse(string(b), "", "", map[string]*parse.Tree{}); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %v", name, err)
+		}
+		walkDefaults(t.Root, func(path, value string) {
+			addDefault(defaults, path, value, filepath.Base(filepath.Dir(name)))
+		})
+	}
+	return defaults, nil
+}
+
+func addDefault(defaults map[string][]templateDefault, path, value, dir string) {
+	for i := range defaults[path] {
+		d := &defaults[path][i]
+		if d.value != value {
+			continue
+		}
+		for _, n := range d.dirs {
+			if n == dir {
+				return
+			}
+		}
+		d.dirs = append(d.dirs, dir)
+		return
+	}
+	defaults[path] = append(defaults[path], templateDefault{value: value, dirs: []string{dir}})
+}
+
+// walkDefaults calls add for each default found in the node,
+// the with and range blocks are skipped as their fields are not relative to the values.
+func walkDefaults(node parse.Node, add func(path, value string)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkDefaults(c, add)
+		}
+	case *parse.ActionNode:
+		if path, value, ok := defaultCommand(n.Pipe); ok {
+			add(path, value)
+		}
+	case *parse.IfNode:
+		if path, value, ok := ifElseDefault(n); ok {
+			add(path, value)
+		}
+		walkDefaults(n.List, add)
+		walkDefaults(n.ElseList, add)
+	}
+}
+
+// defaultCommand recognizes {{ default <value> .<path> }} and {{ .<path> | default <value> }}
+func defaultCommand(pipe *parse.PipeNode) (path, value string, ok bool) {
+	if pipe == nil || len(pipe.Decl) != 0 {
+		return "", "", false
+	}
+	var args []parse.Node
+	switch {
+	case len(pipe.Cmds) == 1 && len(pipe.Cmds[0].Args) == 3:
+		args = pipe.Cmds[0].Args
+	case len(pipe.Cmds) == 2 && len(pipe.Cmds[0].Args) == 1 && len(pipe.Cmds[1].Args) == 2:
+		args = []parse.Node{pipe.Cmds[1].Args[0], pipe.Cmds[1].Args[1], pipe.Cmds[0].Args[0]}
+	default:
+		return "", "", false
+	}
+	if id, isIdentifier := args[0].(*parse.IdentifierNode); !isIdentifier || id.Ident != "default" {
+		return "", "", false
+	}
+	if value, ok = literal(args[1]); !ok {
+		return "", "", false
+	}
+	if path, ok = fieldPath(args[2]); !ok {
+		return "", "", false
+	}
+	return path, value, true
+}
+
+// ifElseDefault recognizes {{ if .<condition> }}{{ .<path> }}{{ else }}<value>{{ end }}
+func ifElseDefault(n *parse.IfNode) (path, value string, ok bool) {
+	if n.Pipe == nil || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 ||
+		n.List == nil || len(n.List.Nodes) != 1 ||
+		n.ElseList == nil || len(n.ElseList.Nodes) != 1 {
+		return "", "", false
+	}
+	condition, ok := fieldPath(n.Pipe.Cmds[0].Args[0])
+	if !ok {
+		return "", "", false
+	}
+	action, isAction := n.List.Nodes[0].(*parse.ActionNode)
+	if !isAction || len(action.Pipe.Cmds) != 1 {
+		return "", "", false
+	}
+	if path, _, ok = defaultCommand(action.Pipe); !ok {
+		if len(action.Pipe.Cmds[0].Args) != 1 {
+			return "", "", false
+		}
+		if path, ok = fieldPath(action.Pipe.Cmds[0].Args[0]); !ok {
+			return "", "", false
+		}
+	}
+	if path != condition && !strings.HasPrefix(path, condition+".") {
+		return "", "", false
+	}
+	text, isText := n.ElseList.Nodes[0].(*parse.TextNode)
+	if !isText {
+		return "", "", false
+	}
+	value = strings.TrimSpace(string(text.Text))
+	if len(value) == 0 || strings.Contains(value, "\n") {
+		return "", "", false
+	}
+	return path, value, true
+}
+
+func fieldPath(node parse.Node) (string, bool) {
+	f, ok := node.(*parse.FieldNode)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(f.Ident, "."), true
+}
+
+func literal(node parse.Node) (string, bool) {
+	switch n := node.(type) {
+	case *parse.StringNode:
+		return n.Text, true
+	case *parse.NumberNode:
+		return n.Text, true
+	case *parse.BoolNode:
+		return fmt.Sprintf("%t", n.True), true
+	}
+	return "", false
+}
+
+// formatDefaults displays a single default as is and several ones with their template directories
+func formatDefaults(defaults []templateDefault) string {
+	if len(defaults) == 1 {
+		return defaults[0].value
+	}
+	values := make([]string, len(defaults))
+	for i, d := range defaults {
+		values[i] = fmt.Sprintf("%s (%s)", d.value, strings.Join(d.dirs, ", "))
+	}
+	return strings.Join(values, ", ")
+}
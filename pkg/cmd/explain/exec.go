@@ -0,0 +1,219 @@
+// Copyright Contributors to the Open Cluster Management project
+package explain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/valuesschemas"
+	"gopkg.in/yaml.v3"
+
+	"github.com/spf13/cobra"
+)
+
+const indent = "           "
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) > 0 {
+		o.Command = valuesschemas.Name(args[0])
+	}
+	if len(args) > 1 {
+		o.Path = strings.Trim(args[1], ".")
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.Command) == 0 {
+		return fmt.Errorf("command is missing")
+	}
+	if _, ok := valuesschemas.Get(o.Command); !ok {
+		return fmt.Errorf("command must be one of %s and got %s", strings.Join(valuesschemas.Names(), ", "), o.Command)
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	s, _ := valuesschemas.Get(o.Command)
+	schema, err := s.Load()
+	if err != nil {
+		return err
+	}
+	field, err := schema.Lookup(o.Path)
+	if err != nil {
+		return err
+	}
+	template, err := loadTemplate(s)
+	if err != nil {
+		return err
+	}
+	value, comment := templateField(template, o.Path)
+	defaults, err := templateDefaults(s)
+	if err != nil {
+		return err
+	}
+
+	w := o.streams.Out
+	fmt.Fprintf(w, "COMMAND:   %s\n", o.Command)
+	if len(o.Path) != 0 {
+		fmt.Fprintf(w, "FIELD:     %s <%s>\n", o.Path, typeName(field))
+	}
+	if d, ok := defaults[o.Path]; ok {
+		fmt.Fprintf(w, "DEFAULT:   %s\n", formatDefaults(d))
+	}
+	// the schema default is the answer suggested by --interactive
+	if field.Default != nil {
+		fmt.Fprintf(w, "SUGGESTED: %v\n", field.Default)
+	}
+	if len(field.Enum) != 0 {
+		enum := make([]string, len(field.Enum))
+		for i := range field.Enum {
+			enum[i] = fmt.Sprintf("%v", field.Enum[i])
+		}
+		fmt.Fprintf(w, "VALUES:    %s\n", strings.Join(enum, ", "))
+	}
+	if field.Minimum != nil {
+		fmt.Fprintf(w, "MINIMUM:   %v\n", *field.Minimum)
+	}
+	if field.Maximum != nil {
+		fmt.Fprintf(w, "MAXIMUM:   %v\n", *field.Maximum)
+	}
+	if len(value) != 0 {
+		fmt.Fprintf(w, "EXAMPLE:   %s\n", value)
+	}
+	if flags := overridingFlags(o.Path, field); len(flags) != 0 {
+		fmt.Fprintf(w, "FLAGS:     %s\n", strings.Join(flags, "\n"+indent))
+	}
+
+	description := field.Description
+	if len(description) == 0 {
+		description = comment
+	}
+	if len(description) != 0 {
+		fmt.Fprintf(w, "\nDESCRIPTION:\n     %s\n", description)
+	}
+
+	for field.Items != nil {
+		field = field.Items
+	}
+	if len(field.Properties) != 0 {
+		fmt.Fprint(w, "\nFIELDS:\n")
+		keys := make([]string, 0, len(field.Properties))
+		for k := range field.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := field.Properties[k]
+			fmt.Fprintf(w, "   %s\t<%s>\n", k, typeName(p))
+			description := p.Description
+			if len(description) == 0 {
+				_, description = templateField(template, joinPath(o.Path, k))
+			}
+			if len(description) != 0 {
+				fmt.Fprintf(w, "     %s\n", description)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+// typeName returns the type of the field as displayed by kubectl explain (ie: []string, map[string]string)
+func typeName(s *helpers.Schema) string {
+	switch {
+	case s.Type == "array" && s.Items != nil:
+		return "[]" + typeName(s.Items)
+	case s.Type == "object" && len(s.Properties) == 0 && s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+		return "map[string]" + typeName(s.AdditionalProperties.Schema)
+	case len(s.Type) == 0:
+		return "any"
+	}
+	return s.Type
+}
+
+// overridingFlags returns the options of the command and the --set options which override the field
+func overridingFlags(path string, s *helpers.Schema) []string {
+	flags := make([]string, 0)
+	if len(s.Flag) != 0 {
+		flags = append(flags, s.Flag)
+	}
+	switch s.Type {
+	case "string":
+		flags = append(flags, fmt.Sprintf("--set-string %s=<value>", path), fmt.Sprintf("--set-file %s=<path>", path))
+	case "integer", "number", "boolean":
+		flags = append(flags, fmt.Sprintf("--set %s=<value>", path))
+	}
+	return flags
+}
+
+// loadTemplate parses the values template of the command, keeping its comments
+func loadTemplate(s valuesschemas.ValuesSchema) (*yaml.Node, error) {
+	b, err := s.Reader.Asset(s.TemplatePath())
+	if err != nil {
+		return nil, err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", s.TemplatePath(), err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{}, nil
+	}
+	return doc.Content[0], nil
+}
+
+// templateField returns the scalar value and the comment of the field in the values template
+func templateField(template *yaml.Node, path string) (value, comment string) {
+	if len(path) == 0 {
+		return "", ""
+	}
+	var key *yaml.Node
+	node := template
+	for _, k := range strings.Split(path, ".") {
+		key, node = mappingEntry(node, k)
+		if node == nil {
+			return "", ""
+		}
+	}
+	for _, c := range []string{node.LineComment, key.LineComment, key.HeadComment} {
+		if len(c) != 0 {
+			comment = trimComment(c)
+			break
+		}
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag != "!!null" && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+		value = node.Value
+	}
+	return value, comment
+}
+
+func mappingEntry(node *yaml.Node, k string) (key, value *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == k {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func joinPath(path, k string) string {
+	if len(path) == 0 {
+		return k
+	}
+	return path + "." + k
+}
+
+// trimComment removes the # of each line of the comment and joins the lines
+func trimComment(c string) string {
+	lines := strings.Split(c, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), "#"))
+	}
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,118 @@
+// Copyright Contributors to the Open Cluster Management project
+package explain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestOptions_run(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "object",
+			args: []string{"create-cluster", "managedCluster.aws.worker.rootVolume"},
+			want: []string{
+				"FIELD:     managedCluster.aws.worker.rootVolume <object>",
+				"root volume of the worker nodes",
+				"   iops\t<integer>\n     IOPS of the root volume",
+			},
+		},
+		{
+			name: "enum with suggestion and template value",
+			args: []string{"create-cluster", "managedCluster.cloud"},
+			want: []string{
+				"SUGGESTED: aws",
+				"VALUES:    aws, azure, gcp, openstack, vsphere",
+				"EXAMPLE:   vsphere",
+				"FLAGS:     --set-string managedCluster.cloud=<value>",
+			},
+		},
+		{
+			name: "template default",
+			args: []string{"create-cluster", "managedCluster.openstack.workerFlavor"},
+			want: []string{
+				"DEFAULT:   m1.xlarge",
+			},
+		},
+		{
+			name: "template defaults depending on the cloud",
+			args: []string{"create-cluster", "managedCluster.worker.replicas"},
+			want: []string{
+				"DEFAULT:   3 (aws, azure, gcp, openstack, vsphere), 0 (baremetal)",
+				"SUGGESTED: 3",
+			},
+		},
+		{
+			name: "field overridden by an option",
+			args: []string{"create-cp", "clusterPool.aws.awsAccessKeyID"},
+			want: []string{
+				"COMMAND:   create-clusterpool",
+				"FLAGS:     --credentials",
+			},
+		},
+		{
+			name: "integer",
+			args: []string{"attach-cluster", "managedCluster.autoImportRetry"},
+			want: []string{
+				"FIELD:     managedCluster.autoImportRetry <integer>",
+				"MINIMUM:   0",
+				"EXAMPLE:   5",
+				"FLAGS:     --set managedCluster.autoImportRetry=<value>",
+			},
+		},
+		{
+			name: "template comment",
+			args: []string{"create-authrealm", "authRealm"},
+			want: []string{
+				"   name\t<string>\n     The name of the authrealm, can be override using the --name parameter",
+				"   matchLabels\t<map[string]string>",
+			},
+		},
+		{
+			name:    "unknown field",
+			args:    []string{"create-cluster", "managedCluster.aws.workr"},
+			wantErr: "managedCluster.aws.workr: unknown field, did you mean worker?",
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"create-clusters"},
+			wantErr: "command must be one of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			o := newOptions(genericclioptionscm.NewCMFlags(nil), genericclioptions.IOStreams{Out: out})
+			err := o.complete(nil, tt.args)
+			if err == nil {
+				err = o.validate()
+			}
+			if err == nil {
+				err = o.run()
+			}
+			if len(tt.wantErr) != 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q and got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("output %q doesn't contain %q", out.String(), s)
+				}
+			}
+		})
+	}
+}
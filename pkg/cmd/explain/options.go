@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package explain
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The command using the values
+	Command string
+	//The dotted path of the field
+	Path    string
+	streams genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright Contributors to the Open Cluster Management project
+package agents
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# List the agents of the current namespace
+%[1]s get agents
+
+# List the agents bound to a cluster
+%[1]s get agents --cluster <cluster_name>
+
+# List the agents not yet bound to a cluster in all namespaces
+%[1]s get agents --unbound -A
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "agents",
+		Aliases:      []string{"agent"},
+		Short:        "list the agents discovered by the assisted installer",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.cluster, "cluster", "", "List only the agents bound to this cluster, the namespace defaults to the cluster name")
+	cmd.Flags().BoolVar(&o.unbound, "unbound", false, "List only the agents not bound to a cluster")
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "If present, list the agents across all namespaces")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+package agents
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/agent"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if o.allNamespaces {
+		return nil
+	}
+	namespace, explicit, err := o.CMFlags.KubectlFactory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	o.namespace = namespace
+	if !explicit && len(o.cluster) != 0 {
+		o.namespace = o.cluster
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.unbound && len(o.cluster) != 0 {
+		return fmt.Errorf("--unbound and --cluster are mutually exclusive")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	dynamicClient, err := o.CMFlags.KubectlFactory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	agents, err := agent.List(dynamicClient, agent.ListOptions{
+		Namespace: o.namespace,
+		Cluster:   o.cluster,
+		Unbound:   o.unbound,
+	})
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		fmt.Fprintln(o.streams.Out, "No agent found")
+		return nil
+	}
+	tw := printers.GetNewTabWriter(o.streams.Out)
+	fmt.Fprintf(tw, "NAMESPACE\tNAME\tHOSTNAME\tCLUSTER\tROLE\tAPPROVED\tSTATE\n")
+	for _, a := range agents {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n", a.Namespace, a.Name, a.Hostname, a.Cluster, a.Role, a.Approved, a.State)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,27 @@
+// Copyright Contributors to the Open Cluster Management project
+package agents
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic options from the cm cli-runtime.
+	CMFlags *genericclioptionscm.CMFlags
+	//The cluster the agents are bound to
+	cluster string
+	//List only the agents not bound to a cluster
+	unbound bool
+	//List the agents of all namespaces
+	allNamespaces bool
+	namespace     string
+	streams       genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+	}
+}
@@ -22,7 +22,13 @@ const (
 	%[1]s get cc  <clusterclaim_name> --cph <clusterpoolhosts>
 	
 	# get clusterclaims across all clusterpoolhosts
-	%[1]s get cc -A`
+	%[1]s get cc -A
+
+	# get my clusterclaims with their creator, description and ticket
+	%[1]s get cc --mine -o wide
+
+	# search clusterclaims by name, clusterpool, description, ticket or lease note
+	%[1]s get cc --search <text>`
 )
 
 // NewCmd ...
@@ -59,6 +65,9 @@ func NewCmd(f cmdutil.Factory, cmFlags *genericclioptionscm.CMFlags, streams gen
 	cmd.Flags().BoolVar(&o.Current, "current", o.Current, "List the clusterclaim which is currently in use")
 	cmd.Flags().BoolVar(&o.KubeConfig, "kubeconfig-creds", o.KubeConfig, "Display the kubeconfig")
 	cmd.Flags().IntVar(&o.Timeout, "timeout", 60, "Timeout to get the cluster claim running")
+	cmd.Flags().BoolVar(&o.Filter.Mine, "mine", false, "List the clusterclaims created by or accessible to the current user")
+	cmd.Flags().StringVar(&o.Filter.Owner, "owner", "", "List the clusterclaims created by or accessible to this user")
+	cmd.Flags().StringVar(&o.Filter.Search, "search", "", "List the clusterclaims having this text in their name, clusterpool, description, ticket or lease note")
 
 	return cmd
 }
@@ -23,6 +23,12 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 }
 
 func (o *Options) validate() error {
+	if o.Filter.Mine && len(o.Filter.Owner) != 0 {
+		return fmt.Errorf("mine and owner are mutually exclusive")
+	}
+	if len(o.ClusterClaim) != 0 && !o.Filter.IsEmpty() {
+		return fmt.Errorf("mine, owner and search can not be used with a clusterclaim name")
+	}
 	return nil
 }
 
@@ -116,6 +122,11 @@ func (o *Options) getCCS(cphs *clusterpoolhost.ClusterPoolHosts) (err error) {
 			fmt.Printf("Error while retrieving clusterclaims from %s\n", cph.Name)
 			continue
 		}
+		clusterClaims, err = cph.FilterClusterClaims(clusterClaims, o.Filter)
+		if err != nil {
+			fmt.Printf("Error while filtering clusterclaims from %s: %s\n", cph.Name, err)
+			continue
+		}
 		printClusterClaimsList := cph.ConvertToPrintClusterClaimList(clusterClaims, o.Current)
 		printClusterClaimLists.Items = append(printClusterClaimLists.Items, printClusterClaimsList.Items...)
 	}
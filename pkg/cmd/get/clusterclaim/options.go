@@ -2,6 +2,7 @@
 package clusterclaim
 
 import (
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/kubectl/pkg/cmd/get"
@@ -18,11 +19,14 @@ type Options struct {
 	Current             bool
 	ClusterPoolHost     string
 	Timeout             int
+	//The filters selecting the clusterclaims to list
+	Filter *clusterpoolhost.ClusterClaimFilter
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
 	return &Options{
 		CMFlags:    cmFlags,
 		GetOptions: get.NewGetOptions("cm", streams),
+		Filter:     &clusterpoolhost.ClusterClaimFilter{},
 	}
 }
@@ -3,6 +3,7 @@ package get
 
 import (
 	"github.com/stolostron/cm-cli/pkg/cmd/get/addon"
+	"github.com/stolostron/cm-cli/pkg/cmd/get/agents"
 	"github.com/stolostron/cm-cli/pkg/cmd/get/clusterclaim"
 	"github.com/stolostron/cm-cli/pkg/cmd/get/clusterpoolhosts"
 	"github.com/stolostron/cm-cli/pkg/cmd/get/clusterpools"
@@ -47,6 +48,7 @@ func NewCmd(f cmdutil.Factory, clusteradmFlags *genericclioptionsclusteradm.Clus
 	cmd.AddCommand(contexts.NewCmd(f, cmFlags, streams))
 	cmd.AddCommand(components.NewCmd(cmFlags, streams))
 	cmd.AddCommand(addon.NewCmd(clusteradmFlags, cmFlags, streams))
+	cmd.AddCommand(agents.NewCmd(cmFlags, streams))
 
 	return cmd
 }
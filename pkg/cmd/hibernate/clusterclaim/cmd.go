@@ -20,6 +20,9 @@ var example = `
 
 # run clusterclaims on a given clusterpoolhost
 %[1]s hibernate cc <clusterclaim_name>[,<clusterclaim_name>...] --cph <clusterpoolhost> <options>
+
+# Hibernate clusterclaims after draining the workloads
+%[1]s hibernate cc <clusterclaim_name>[,<clusterclaim_name>...] --pre-hook ./drain.sh
 `
 
 // NewCmd ...
@@ -47,6 +50,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	}
 
 	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Hibernate the clusterclaims even if they are checked out by another user or a hook fails")
+	cmd.Flags().StringArrayVar(&o.PreHooks, "pre-hook", nil, "Script or manifests file or directory to run before the hibernation, replaces the pre-hibernate hooks of the clusterpoolhost")
+	cmd.Flags().StringArrayVar(&o.PostHooks, "post-hook", nil, "Script or manifests file or directory to run once the clusters are hibernated, replaces the post-hibernate hooks of the clusterpoolhost")
+	cmd.Flags().IntVar(&o.Timeout, "timeout", 60, "Timeout in minutes to wait for the hibernation before running the post hooks")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
 	cmd.Flags().BoolVar(&o.SkipSchedule, "skip-schedule", false, "Set the hibernation schedule to skip (deprecated)")
 	cmd.Flags().BoolVar(&o.HibernateScheduleOn, "hibernate-schedule-on", false, "Set the hibernation schedule to on")
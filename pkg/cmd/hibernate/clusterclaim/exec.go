@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 
 	"github.com/spf13/cobra"
 )
@@ -43,5 +44,9 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
-	return cph.HibernateClusterClaims(o.ClusterClaims, scheduleSkip, o.CMFlags.DryRun)
+	hooks := cph.Hooks.Override(&helpers.Hooks{
+		PreHibernate:  o.PreHooks,
+		PostHibernate: o.PostHooks,
+	})
+	return cph.HibernateClusterClaims(o.ClusterClaims, scheduleSkip, hooks, o.Timeout, o.Force, o.CMFlags.DryRun)
 }
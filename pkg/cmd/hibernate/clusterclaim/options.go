@@ -13,6 +13,13 @@ type Options struct {
 	ClusterClaims   string
 	ClusterPoolHost string
 	SkipSchedule    bool
+	//Force the operation even if the clusterclaims are checked out by another user or a hook fails
+	Force bool
+	//The hooks to run before and after the hibernation, they replace the ones of the clusterpoolhost
+	PreHooks  []string
+	PostHooks []string
+	//Timeout in minutes to wait for the hibernation before running the post hooks
+	Timeout int
 	// hibernate schedule on
 	HibernateScheduleOn bool
 	// hibernate schedule off
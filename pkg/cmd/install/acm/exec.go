@@ -4,6 +4,7 @@ package acm
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -20,11 +21,14 @@ import (
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if o.exportFlags.IsSet() {
+		o.CMFlags.DryRun = true
+	}
 	return nil
 }
 
 func (o *Options) validate() error {
-	return nil
+	return o.exportFlags.Validate()
 }
 
 func (o *Options) run() (err error) {
@@ -93,14 +97,17 @@ func (o *Options) runWithClient(restConfig *rest.Config,
 
 	//Wait MCH CRD to be created
 	if !o.CMFlags.DryRun {
+		progress := helpers.NewProgress(os.Stdout)
 		err = wait.PollImmediate(10*time.Second, time.Duration(3)*time.Minute, func() (bool, error) {
 			_, err := apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), "multiclusterhubs.operator.open-cluster-management.io", metav1.GetOptions{})
 			if err != nil {
-				fmt.Printf("%s, waiting...\n", err)
+				progress.Update("crd multiclusterhubs.operator.open-cluster-management.io", "Waiting", err.Error())
 				return false, nil
 			}
+			progress.Done("crd multiclusterhubs.operator.open-cluster-management.io", "Created", "")
 			return true, nil
 		})
+		progress.Stop()
 
 		if err != nil {
 			return err
@@ -116,27 +123,36 @@ func (o *Options) runWithClient(restConfig *rest.Config,
 	}
 	output = append(output, out...)
 
+	// the resources are written even if the wait fails
+	var waitErr error
 	if o.wait {
-		i := 0
-		wait.PollImmediate(1*time.Minute, time.Duration(o.timeout)*time.Minute, func() (bool, error) {
+		progress := helpers.NewProgress(os.Stdout)
+		start := time.Now()
+		err = wait.PollImmediate(1*time.Minute, time.Duration(o.timeout)*time.Minute, func() (bool, error) {
 			mchu, err := dynamicClient.Resource(helpers.GvrMCH).Namespace(o.namespace).Get(context.TODO(), "multiclusterhub", metav1.GetOptions{})
 			if err != nil {
-				fmt.Printf("%s, waiting...\n", err)
+				progress.Update("multiclusterhub", "Waiting", err.Error())
 				return false, nil
 			}
-			i += 1
-			if statusu, ok := mchu.Object["status"]; ok {
-				status := statusu.(map[string]interface{})
-				if phaseu, ok := status["phase"]; ok {
-					phase := phaseu.(string)
-					if phase != "Running" {
-						fmt.Printf("(%d/%d), phase is %s, waiting for Running", i, o.timeout, phase)
-						return false, nil
-					}
-				}
+			phase, reason := helpers.UnstructuredPhaseAndReason(mchu.Object)
+			if phase != "Running" {
+				progress.Update("multiclusterhub", phase, reason)
+				return false, nil
 			}
+			progress.Done("multiclusterhub", phase, reason)
 			return true, nil
 		})
+		progress.Stop()
+		helpers.NotifyWaitDone("multiclusterhub "+o.namespace, start, err)
+		if err != nil {
+			waitErr = fmt.Errorf("the multiclusterhub is not running after %d minutes: %v", o.timeout, err)
+		}
+	}
+	if err := o.exportFlags.Export(output); err != nil {
+		return err
+	}
+	if err := helpers.WriteOutput(o.CMFlags, applierBuilder, o.outputFile, output); err != nil {
+		return err
 	}
-	return apply.WriteOutput(o.outputFile, output)
+	return waitErr
 }
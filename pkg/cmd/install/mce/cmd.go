@@ -60,6 +60,8 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd.Flags().StringVar(&o.namespace, "namespace", "multicluster-engine", "The namespace where to install MCE")
 	cmd.Flags().StringVar(&o.operatorGroup, "operatorGroup", "multicluster-engine", "The operator group")
 	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+	cmFlags.AddServerDryRunFlags(cmd.Flags())
+	o.exportFlags.AddFlags(cmd.Flags())
 	cmd.Flags().BoolVar(&o.wait, "wait", false, "Wait until MCE installed is completed")
 	cmd.Flags().BoolVar(&o.manualApproval, "manual-approval", false, "Set for manual approval otherwize automatic")
 	cmd.Flags().IntVar(&o.timeout, "timeout", 30, "Timeout to get MCE installed in minutes")
@@ -0,0 +1,52 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Display the logs of the latest provision of a cluster
+%[1]s logs cluster <cluster_name>
+
+# Stream the logs of the latest provision of a cluster
+%[1]s logs cluster <cluster_name> -f
+
+# Display the installer errors of the latest provision of a cluster
+%[1]s logs cluster <cluster_name> --errors
+
+# Save the deprovision logs of a cluster in a file
+%[1]s logs cluster <cluster_name> --deprovision --output-file <file>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "cluster",
+		Aliases:      []string{"clusters"},
+		Short:        "print the hive provision or deprovision logs of a cluster",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.LogsOptions.Deprovision, "deprovision", false, "Display the deprovision logs instead of the provision logs")
+	cmd.Flags().BoolVarP(&o.LogsOptions.Follow, "follow", "f", false, "Specify if the logs should be streamed")
+	cmd.Flags().Int64Var(&o.LogsOptions.TailLines, "tail", -1, "Lines of recent log file to display. Defaults to -1, showing all log lines")
+	cmd.Flags().BoolVar(&o.LogsOptions.ErrorsOnly, "errors", false, "Display only the last installer error lines")
+	cmd.Flags().StringVar(&o.LogsOptions.OutputFile, "output-file", "", "The logs will be saved in the specified file")
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("cluster name is missing")
+	}
+	o.Cluster = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.LogsOptions.Follow && o.LogsOptions.ErrorsOnly {
+		return fmt.Errorf("flags follow and errors are mutually exclusive")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	dynamicClient, err := o.CMFlags.KubectlFactory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := o.CMFlags.KubectlFactory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	return clusterdeployment.WriteLogs(dynamicClient, kubeClient, o.Cluster, o.LogsOptions, o.streams.Out, o.streams.ErrOut)
+}
@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags     *genericclioptionscm.CMFlags
+	Cluster     string
+	LogsOptions *clusterdeployment.LogsOptions
+	streams     genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags:     cmFlags,
+		LogsOptions: &clusterdeployment.LogsOptions{},
+		streams:     streams,
+	}
+}
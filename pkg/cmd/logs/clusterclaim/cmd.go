@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Display the logs of the latest provision of a clusterclaim
+%[1]s logs cc <clusterclaim_name>
+
+# Display the installer errors of a clusterclaim on a given clusterpoolhost
+%[1]s logs cc <clusterclaim_name> --cph <clusterpoolhost> --errors
+
+# Save the deprovision logs of a clusterclaim in a file
+%[1]s logs cc <clusterclaim_name> --deprovision --output-file <file>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "clusterclaim",
+		Aliases:      []string{"clusterclaims", "cc", "ccs"},
+		Short:        "print the hive provision or deprovision logs of a clusterclaim",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().BoolVar(&o.LogsOptions.Deprovision, "deprovision", false, "Display the deprovision logs instead of the provision logs")
+	cmd.Flags().BoolVarP(&o.LogsOptions.Follow, "follow", "f", false, "Specify if the logs should be streamed")
+	cmd.Flags().Int64Var(&o.LogsOptions.TailLines, "tail", -1, "Lines of recent log file to display. Defaults to -1, showing all log lines")
+	cmd.Flags().BoolVar(&o.LogsOptions.ErrorsOnly, "errors", false, "Display only the last installer error lines")
+	cmd.Flags().StringVar(&o.LogsOptions.OutputFile, "output-file", "", "The logs will be saved in the specified file")
+
+	return cmd
+}
@@ -0,0 +1,24 @@
+// Copyright Contributors to the Open Cluster Management project
+package logs
+
+import (
+	"github.com/stolostron/cm-cli/pkg/cmd/logs/cluster"
+	"github.com/stolostron/cm-cli/pkg/cmd/logs/clusterclaim"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping NewCmdImportCluster
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "print the provision or deprovision logs of a resource",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(cmFlags, streams))
+	cmd.AddCommand(clusterclaim.NewCmd(cmFlags, streams))
+
+	return cmd
+}
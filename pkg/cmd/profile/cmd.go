@@ -0,0 +1,30 @@
+// Copyright Contributors to the Open Cluster Management project
+package profile
+
+import (
+	"github.com/stolostron/cm-cli/pkg/cmd/profile/delete"
+	profileimport "github.com/stolostron/cm-cli/pkg/cmd/profile/import"
+	"github.com/stolostron/cm-cli/pkg/cmd/profile/list"
+	"github.com/stolostron/cm-cli/pkg/cmd/profile/save"
+	"github.com/stolostron/cm-cli/pkg/cmd/profile/show"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping NewCmdImportCluster
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "manage the local profiles used by create cluster, create clusterpool and create hypershiftdeployment",
+	}
+
+	cmd.AddCommand(save.NewCmd(cmFlags, streams))
+	cmd.AddCommand(list.NewCmd(cmFlags, streams))
+	cmd.AddCommand(show.NewCmd(cmFlags, streams))
+	cmd.AddCommand(delete.NewCmd(cmFlags, streams))
+	cmd.AddCommand(profileimport.NewCmd(cmFlags, streams))
+
+	return cmd
+}
@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package delete
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/profile"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("profile name is missing")
+	}
+	o.Profile = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	return profile.ValidateName(o.Profile)
+}
+
+func (o *Options) run() (err error) {
+	if err := profile.Delete(o.Profile); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.streams.Out, "profile %s deleted\n", o.Profile)
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright Contributors to the Open Cluster Management project
+package profileimport
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Import the shape of a cluster as a profile
+%[1]s get config cluster mycluster --without-credentials | %[1]s profile import aws-small-us-east
+
+# Import a profile from a saved configuration
+%[1]s profile import aws-small-us-east -f mycluster-config.yaml
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "import",
+		Short:        "import a profile from a cluster configuration",
+		Long:         "Import as a profile the output of 'get config cluster', the name, ssh keys, pull secret and cloud credentials of the cluster are removed",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "Replace the profile if it already exists")
+
+	return cmd
+}
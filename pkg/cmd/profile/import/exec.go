@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+package profileimport
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/profile"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("profile name is missing")
+	}
+	o.Profile = args[0]
+	o.values, err = o.valuesFlags.ToValuesMap()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := profile.ValidateName(o.Profile); err != nil {
+		return err
+	}
+	if _, ok := o.values["managedCluster"]; !ok {
+		return fmt.Errorf("managedCluster is missing, the configuration must be the output of 'get config cluster'")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	profile.RemoveClusterFields(o.values)
+	p := &profile.Profile{Name: o.Profile, Values: o.values}
+	if err := p.Save(o.Overwrite); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.streams.Out, "profile %s imported\n", o.Profile)
+	return nil
+}
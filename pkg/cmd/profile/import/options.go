@@ -0,0 +1,29 @@
+// Copyright Contributors to the Open Cluster Management project
+package profileimport
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags     *genericclioptionscm.CMFlags
+	Profile     string
+	valuesFlags helpers.ValuesFlags
+	values      map[string]interface{}
+	//Replace an existing profile
+	Overwrite bool
+	streams   genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+		valuesFlags: helpers.ValuesFlags{
+			KeepReferences: true,
+		},
+	}
+}
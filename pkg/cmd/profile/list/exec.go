@@ -0,0 +1,36 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/profile"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	return nil
+}
+
+func (o *Options) validate() error {
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	profiles, err := profile.List()
+	if err != nil {
+		return err
+	}
+	if len(profiles) == 0 {
+		fmt.Fprintln(o.streams.Out, "No profile found")
+		return nil
+	}
+	tw := printers.GetNewTabWriter(o.streams.Out)
+	fmt.Fprintf(tw, "NAME\tSECTIONS\n")
+	for _, p := range profiles {
+		fmt.Fprintf(tw, "%s\t%s\n", p.Name, strings.Join(p.Sections(), ","))
+	}
+	return tw.Flush()
+}
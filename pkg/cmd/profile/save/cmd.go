@@ -0,0 +1,43 @@
+// Copyright Contributors to the Open Cluster Management project
+package save
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Save a profile from a values file
+%[1]s profile save aws-small-us-east -f aws-small-us-east.yaml
+
+# Save a profile from a base values file and overrides
+%[1]s profile save aws-large-us-east -f aws-small-us-east.yaml --set managedCluster.worker.replicas=6 --overwrite
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "save",
+		Short:        "save a profile",
+		Long:         "Save a values fragment as a local profile, the ${...} references of the values are kept unresolved",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "Replace the profile if it already exists")
+
+	return cmd
+}
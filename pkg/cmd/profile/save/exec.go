@@ -0,0 +1,40 @@
+// Copyright Contributors to the Open Cluster Management project
+package save
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/profile"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("profile name is missing")
+	}
+	o.Profile = args[0]
+	o.values, err = o.valuesFlags.ToValuesMap()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := profile.ValidateName(o.Profile); err != nil {
+		return err
+	}
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	p := &profile.Profile{Name: o.Profile, Values: o.values}
+	if err := p.Save(o.Overwrite); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.streams.Out, "profile %s saved\n", o.Profile)
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/profile"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("profile name is missing")
+	}
+	o.Profile = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	return profile.ValidateName(o.Profile)
+}
+
+func (o *Options) run() (err error) {
+	p, err := profile.Get(o.Profile)
+	if err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(p.Values)
+	if err != nil {
+		return err
+	}
+	_, err = o.streams.Out.Write(b)
+	return err
+}
@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Report the clusterclaims older than 7 days without acting on them
+%[1]s reap clusterclaims --older-than 168h --dry-run
+
+# Hibernate the clusterclaims of a clusterpool created more than 2 days ago
+%[1]s reap clusterclaims --older-than 48h --pool <clusterpool_name>
+
+# Delete the clusterclaims hibernating for more than 7 days across all clusterpoolhosts
+%[1]s reap clusterclaims --idle-hibernated-for 168h --action delete -A
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:     "clusterclaim",
+		Aliases: []string{"clusterclaims", "cc", "ccs"},
+		Short:   "hibernate or delete stale clusterclaims",
+		Long: fmt.Sprintf("Report the clusterclaims matching all the filters then hibernate or delete them.\n"+
+			"Clusterclaims checked out by a user or having the %s annotation set to a future RFC3339 time or \"always\" are skipped.",
+			clusterpoolhost.KeepUntilAnnotation),
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
+	cmd.Flags().BoolVarP(&o.AllClusterPoolHosts, "all-cphs", "A", false, "Reap the clusterclaims across all clusterpoolhosts")
+	cmd.Flags().DurationVar(&o.ReapOptions.OlderThan, "older-than", 0, "Select the clusterclaims created more than this duration ago")
+	cmd.Flags().StringVar(&o.ReapOptions.ClusterPoolName, "pool", "", "Select the clusterclaims of this clusterpool")
+	cmd.Flags().DurationVar(&o.ReapOptions.IdleHibernatedFor, "idle-hibernated-for", 0, "Select the clusterclaims hibernating for more than this duration")
+	cmd.Flags().StringVar(&o.ReapOptions.Owner, "owner", "", "Select the clusterclaims having this user or service account as subject")
+	cmd.Flags().StringVar(&o.Action, "action", clusterpoolhost.ReapActionHibernate,
+		fmt.Sprintf("The action to apply on the selected clusterclaims (%s or %s)", clusterpoolhost.ReapActionHibernate, clusterpoolhost.ReapActionDelete))
+
+	return cmd
+}
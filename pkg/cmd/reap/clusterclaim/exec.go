@@ -0,0 +1,97 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	return nil
+}
+
+func (o *Options) validate() error {
+	if o.ClusterPoolHost != "" && o.AllClusterPoolHosts {
+		return fmt.Errorf("clusterpoolhost and all-cphs are imcompatible")
+	}
+	if o.Action != clusterpoolhost.ReapActionHibernate && o.Action != clusterpoolhost.ReapActionDelete {
+		return fmt.Errorf("action must be %s or %s", clusterpoolhost.ReapActionHibernate, clusterpoolhost.ReapActionDelete)
+	}
+	if o.ReapOptions.OlderThan <= 0 && o.ReapOptions.IdleHibernatedFor <= 0 {
+		return fmt.Errorf("at least one of older-than or idle-hibernated-for must be set")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	cphs := &clusterpoolhost.ClusterPoolHosts{}
+	if o.AllClusterPoolHosts {
+		cphs, err = clusterpoolhost.GetClusterPoolHosts()
+		if err != nil {
+			return err
+		}
+	} else {
+		cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(o.ClusterPoolHost)
+		if err != nil {
+			return err
+		}
+		cphs.ClusterPoolHosts = map[string]*clusterpoolhost.ClusterPoolHost{
+			cph.Name: cph,
+		}
+	}
+
+	cphNames := make([]string, 0)
+	for name := range cphs.ClusterPoolHosts {
+		cphNames = append(cphNames, name)
+	}
+	sort.Strings(cphNames)
+
+	candidates := make(map[string][]clusterpoolhost.ReapCandidate)
+	errs := make([]string, 0)
+	tw := printers.GetNewTabWriter(o.streams.Out)
+	fmt.Fprintf(tw, "CLUSTER_POOL_HOST\tCLUSTER_CLAIM\tCLUSTER_POOL\tAGE\tPOWER_STATE\tACTION\n")
+	for _, name := range cphNames {
+		cph := cphs.ClusterPoolHosts[name]
+		cs, err := cph.GetClusterClaimsToReap(o.ReapOptions)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("clusterpoolhost %s: %s", name, err))
+			continue
+		}
+		candidates[name] = cs
+		for _, c := range cs {
+			action := o.Action
+			if len(c.SkipReason) != 0 {
+				action = fmt.Sprintf("skip (%s)", c.SkipReason)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				name,
+				c.ClusterClaim.Name,
+				c.ClusterClaim.Spec.ClusterPoolName,
+				helpers.TimeDiff(c.ClusterClaim.CreationTimestamp.Time, time.Minute),
+				c.PowerState,
+				action)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, name := range cphNames {
+		if err := cphs.ClusterPoolHosts[name].ReapClusterClaims(candidates[name], o.Action, o.CMFlags.DryRun); err != nil {
+			errs = append(errs, fmt.Sprintf("clusterpoolhost %s: %s", name, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
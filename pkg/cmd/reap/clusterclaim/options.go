@@ -0,0 +1,28 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterclaim
+
+import (
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags             *genericclioptionscm.CMFlags
+	ClusterPoolHost     string
+	AllClusterPoolHosts bool
+	//The filters selecting the clusterclaims
+	ReapOptions *clusterpoolhost.ReapOptions
+	//hibernate or delete
+	Action  string
+	streams genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags:     cmFlags,
+		ReapOptions: &clusterpoolhost.ReapOptions{},
+		streams:     streams,
+	}
+}
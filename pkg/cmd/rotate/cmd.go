@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package rotate
+
+import (
+	"github.com/stolostron/cm-cli/pkg/cmd/rotate/credentials"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping NewCmdImportCluster
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "rotate a resource",
+	}
+
+	cmd.AddCommand(credentials.NewCmd(cmFlags, streams))
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	"fmt"
+	"path/filepath"
+
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/stolostron/cm-cli/pkg/credentials"
+	"github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var valuesTemplatePath = filepath.Join("create", "values-template.yaml")
+var valuesSchemaPath = filepath.Join("create", helpers.ValuesSchemaFileName)
+
+var example = `
+# Update the credentials then the clusterpool and clusterdeployment secrets created with them
+%[1]s rotate credentials <credentials_name> --values values.yaml
+
+# Propagate credentials already updated on a clusterpoolhost to its clusterpool secrets
+%[1]s rotate credentials <credentials_name> --cph <clusterpoolhost_name>
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:     "credentials",
+		Aliases: []string{"cred", "creds"},
+		Short:   "Rotate the credentials of a cloud provider",
+		Long: fmt.Sprintf("Update the credentials with the values if set, "+
+			"then copy them in the secrets labelled %s and %s with the credentials.\n"+
+			"On the hub the secrets of all namespaces are updated, on a clusterpoolhost the ones of its namespace.",
+			credentials.CopiedFromNamespaceLabel, credentials.CopiedFromSecretNameLabel),
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.SetUsageTemplate(clusteradmhelpers.UsageTempate(cmd, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
+	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "Rotate the credentials in the namespace of this clusterpoolhost instead of the hub")
+	o.valuesFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "", "The generated resources will be copied in the specified file")
+
+	return cmd
+}
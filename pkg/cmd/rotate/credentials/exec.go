@@ -0,0 +1,95 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	"fmt"
+
+	"github.com/stolostron/cm-cli/pkg/credentials"
+	"github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("credentials name is missing")
+	}
+	o.Credentials = args[0]
+
+	if o.valuesFlags.HasFiles() {
+		o.values, err = o.valuesFlags.ToValuesMap()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	if !o.valuesFlags.HasFiles() {
+		return nil
+	}
+	if err := helpers.ValidateValues(scenario.GetScenarioResourcesReader(), valuesSchemaPath, o.values); err != nil {
+		return err
+	}
+	ic, ok := o.values["credentials"]
+	if !ok || ic == nil {
+		return fmt.Errorf("credentials is missing")
+	}
+	if _, ok := ic.(map[string]interface{}); !ok {
+		return fmt.Errorf("credentials is not a map")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	target, err := credentials.NewTarget(o.CMFlags, o.ClusterPoolHost)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(target.RestConfig)
+	if err != nil {
+		return err
+	}
+	secret, err := credentials.Get(kubeClient, target.Namespace, o.Credentials)
+	if err != nil {
+		return err
+	}
+
+	if o.values != nil {
+		c := o.values["credentials"].(map[string]interface{})
+		cloud := credentials.Cloud(secret)
+		if ic, ok := c["cloud"]; ok && ic != nil && ic != cloud {
+			return fmt.Errorf("credentials %s/%s are for %s and can not be rotated to %v", secret.Namespace, secret.Name, cloud, ic)
+		}
+		c["cloud"] = cloud
+		c["name"] = secret.Name
+		c["namespace"] = secret.Namespace
+		if ic, ok := c[cloud]; !ok || ic == nil {
+			return fmt.Errorf("credentials.%s is missing", cloud)
+		}
+		if err = target.Apply(o.values, o.CMFlags, o.outputFile); err != nil {
+			return err
+		}
+		//Render the new credentials to compute the updates in dry-run too
+		secret, err = credentials.Render(o.values)
+		if err != nil {
+			return err
+		}
+	}
+
+	updated, err := credentials.Rotate(kubeClient, secret, target.Scope, o.CMFlags.DryRun)
+	for _, s := range updated {
+		fmt.Fprintf(o.streams.Out, "secret %s updated\n", s)
+	}
+	if err != nil {
+		return err
+	}
+	if len(updated) == 0 {
+		fmt.Fprintf(o.streams.Out, "no secret to update for credentials %s/%s\n", target.Namespace, o.Credentials)
+	}
+	return nil
+}
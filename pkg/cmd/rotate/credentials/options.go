@@ -0,0 +1,27 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags         *genericclioptionscm.CMFlags
+	Credentials     string
+	ClusterPoolHost string
+	valuesFlags     helpers.ValuesFlags
+	values          map[string]interface{}
+	//The file to output the resources will be sent to the file.
+	outputFile string
+	streams    genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+	}
+}
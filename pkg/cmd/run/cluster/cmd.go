@@ -17,6 +17,9 @@ import (
 var example = `
 # Run clusters
 %[1]s run cluster <cluster_name>[,<clusterc_name>...] <options>
+
+# Run clusters and wait until they are running
+%[1]s run cluster <cluster_name>[,<clusterc_name>...] --wait --timeout 30
 `
 
 // NewCmd ...
@@ -39,5 +42,11 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 		},
 	}
 
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Wait until the clusters are running")
+	cmd.Flags().IntVar(&o.Timeout, "timeout", 60, "Timeout in minutes to wait for the clusters")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Run the clusters even if a hook fails")
+	cmd.Flags().StringArrayVar(&o.PreHooks, "pre-hook", nil, "Script to run before the resume")
+	cmd.Flags().StringArrayVar(&o.PostHooks, "post-hook", nil, "Script or manifests file or directory to run once the clusters are running, implies --wait")
+
 	return cmd
 }
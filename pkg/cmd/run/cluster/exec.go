@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/cm-cli/pkg/clusterdeployment"
 	"github.com/stolostron/cm-cli/pkg/helpers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,6 +24,9 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 }
 
 func (o *Options) validate() error {
+	if o.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
 	return nil
 }
 
@@ -33,8 +37,25 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
+	kubeClient, err := o.CMFlags.KubectlFactory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+
+	clusterNames := make([]string, 0)
 	for _, ccn := range strings.Split(o.Clusters, ",") {
-		ccn := strings.TrimSpace(ccn)
+		clusterNames = append(clusterNames, strings.TrimSpace(ccn))
+	}
+	hooks := &helpers.Hooks{
+		PreRun:  o.PreHooks,
+		PostRun: o.PostHooks,
+	}
+	err = clusterdeployment.RunHooks(kubeClient, dynamicClient, clusterNames, hooks, helpers.HookPhasePreRun, o.Timeout, o.Force, o.CMFlags.DryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, ccn := range clusterNames {
 		cdu, err := dynamicClient.Resource(helpers.GvrCD).Namespace(ccn).Get(context.TODO(), ccn, metav1.GetOptions{})
 		if err != nil {
 			return err
@@ -57,5 +78,9 @@ func (o *Options) run() (err error) {
 			}
 		}
 	}
-	return nil
+	// the post hooks wait for the power state
+	if o.Wait && len(o.PostHooks) == 0 && !o.CMFlags.DryRun {
+		return clusterdeployment.WaitPowerState(dynamicClient, clusterNames, hivev1.ClusterPowerStateRunning, o.Timeout)
+	}
+	return clusterdeployment.RunHooks(kubeClient, dynamicClient, clusterNames, hooks, helpers.HookPhasePostRun, o.Timeout, o.Force, o.CMFlags.DryRun)
 }
@@ -11,6 +11,15 @@ type Options struct {
 	CMFlags *genericclioptionscm.CMFlags
 	// list of cluster names (comma-separated)
 	Clusters string
+	// wait until the clusters reach the power state
+	Wait bool
+	// timeout in minutes of the wait
+	Timeout int
+	// continue even if a hook fails
+	Force bool
+	// the hooks to run before and after the power state transition
+	PreHooks  []string
+	PostHooks []string
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
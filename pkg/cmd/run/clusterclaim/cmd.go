@@ -21,6 +21,9 @@ var example = `
 
 # run clusterclaims on a given clusterpoolhost
 %[1]s run cc <clusterclaim_name>[,<clusterclaim_name>...] --cph <clusterpoolhost> <options>
+
+# Run clusterclaims and approve the pending CSRs once they are running
+%[1]s run cc <clusterclaim_name>[,<clusterclaim_name>...] --post-hook ./approve-csrs.sh
 `
 
 // NewCmd ...
@@ -53,6 +56,9 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd.Flags().BoolVar(&o.HibernateScheduleOn, "hibernate-schedule-on", false, "Set the hibernation schedule to on")
 	cmd.Flags().BoolVar(&o.HibernateScheduleOff, "hibernate-schedule-off", false, "Set the hibernation schedule to off")
 	cmd.Flags().IntVar(&o.Timeout, "timeout", 60, "Timeout to get the cluster claim running")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Run the clusterclaims even if a hook fails")
+	cmd.Flags().StringArrayVar(&o.PreHooks, "pre-hook", nil, "Script to run before the resume, replaces the pre-run hooks of the clusterpoolhost")
+	cmd.Flags().StringArrayVar(&o.PostHooks, "post-hook", nil, "Script or manifests file or directory to run once the clusters are running, replaces the post-run hooks of the clusterpoolhost")
 	cmd.Flags().BoolVar(&o.WithCredentials, "creds", o.WithCredentials, "If set the credentials will be displayed")
 	return cmd
 }
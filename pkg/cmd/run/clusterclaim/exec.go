@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 
 	"github.com/spf13/cobra"
 )
@@ -44,7 +45,11 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
-	err = cph.RunClusterClaims(o.ClusterClaims, scheduleSkip, o.Timeout, o.CMFlags.DryRun, o.outputFile, o.GetOptions.PrintFlags)
+	hooks := cph.Hooks.Override(&helpers.Hooks{
+		PreRun:  o.PreHooks,
+		PostRun: o.PostHooks,
+	})
+	err = cph.RunClusterClaims(o.ClusterClaims, scheduleSkip, hooks, o.Timeout, o.Force, o.CMFlags.DryRun, o.outputFile, o.GetOptions.PrintFlags)
 	if err != nil {
 		return err
 	}
@@ -21,6 +21,11 @@ type Options struct {
 	HibernateScheduleOff bool
 	SkipSchedule         bool
 	Timeout              int
+	//Force the operation even if a hook fails
+	Force bool
+	//The hooks to run before and after the resume, they replace the ones of the clusterpoolhost
+	PreHooks  []string
+	PostHooks []string
 	//The file to output the resources will be sent to the file.
 	outputFile string
 }
@@ -19,6 +19,12 @@ import (
 var example = `
 # Scale a cluster
 %[1]s scale cluster --cluster clustername --machinepool poolname --replicas 4
+
+# Switch a machinepool to autoscaling
+%[1]s scale cluster --cluster clustername --machinepool poolname --min 2 --max 6
+
+# Scale a machinepool of a clusterclaim on a given clusterpoolhost
+%[1]s scale cluster --cluster clusterclaimname --cph clusterpoolhostname --machinepool worker --replicas 4
 `
 
 const (
@@ -38,6 +44,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRunE: func(c *cobra.Command, args []string) error {
+			if o.clusterClaim || len(o.clusterPoolHost) != 0 {
+				clusteradmhelpers.DryRunMessage(cmFlags.DryRun)
+				return nil
+			}
 			isSupported, err := helpers.IsSupported(o.CMFlags)
 			if err != nil {
 				return err
@@ -68,9 +78,14 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	}
 
 	cluster.SetUsageTemplate(clusteradmhelpers.UsageTempate(cluster, scenario.GetScenarioResourcesReader(), valuesTemplatePath))
-	cluster.Flags().StringVar(&o.valuesPath, "values", "", "The files containing the values")
+	o.valuesFlags.AddFlags(cluster.Flags())
 	cluster.Flags().StringVar(&o.clusterName, "cluster", "", "Name of the cluster")
+	cluster.Flags().StringVar(&o.machinePoolName, "machinepool", "", "Name of the machinepool")
 	cluster.Flags().IntVar(&o.replicas, "replicas", 3, "number of workers for the pool")
+	cluster.Flags().IntVar(&o.minReplicas, "min", 0, "minimum number of workers, switches the pool to autoscaling with --max")
+	cluster.Flags().IntVar(&o.maxReplicas, "max", 0, "maximum number of workers, switches the pool to autoscaling")
+	cluster.Flags().StringVar(&o.clusterPoolHost, "cph", "", "The clusterpoolhost of the clusterclaim, implies --clusterclaim")
+	cluster.Flags().BoolVar(&o.clusterClaim, "clusterclaim", false, "The cluster is a clusterclaim of the active clusterpoolhost")
 
 	return cluster
 }
@@ -2,34 +2,31 @@
 package cluster
 
 import (
-	"context"
 	"fmt"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/discovery"
-	"k8s.io/client-go/dynamic"
-
 	"github.com/spf13/cobra"
 	"github.com/stolostron/cm-cli/pkg/cmd/scale/cluster/scenario"
 	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/machinepool"
 )
 
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	var mc map[string]interface{}
-	if o.valuesPath == "" {
+	if !o.valuesFlags.HasFiles() {
 		reader := scenario.GetScenarioResourcesReader()
 		o.values, err = helpers.ConvertReaderFileToValuesMap(valuesDefaultPath, reader)
 		if err != nil {
 			return err
 		}
 	} else {
-		o.values, err = helpers.ConvertValuesFileToValuesMap(o.valuesPath, "")
+		o.values, err = o.valuesFlags.ReadFiles()
 		if err != nil {
 			return err
 		}
 	}
+	if err = o.valuesFlags.ApplyOverrides(o.values); err != nil {
+		return err
+	}
 	if imc, ok := o.values["managedCluster"]; !ok {
 		return fmt.Errorf("managedCluster is missing")
 	} else {
@@ -48,6 +45,13 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	if cmd.Flags().Changed("replicas") {
 		mc["replicas"] = o.replicas
 	}
+	if cmd.Flags().Changed("min") || cmd.Flags().Changed("max") {
+		if cmd.Flags().Changed("replicas") {
+			return fmt.Errorf("--replicas and --min/--max are mutually exclusive")
+		}
+		mc["minReplicas"] = o.minReplicas
+		mc["maxReplicas"] = o.maxReplicas
+	}
 
 	//Align parameters with values
 	if mc["name"] != nil {
@@ -56,12 +60,9 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	if mc["machinepool"] != nil {
 		o.machinePoolName = mc["machinepool"].(string)
 	}
-	switch i := mc["replicas"].(type) {
-	case float64:
-		o.replicas = int(i)
-	case int:
-		o.replicas = i
-	}
+	o.replicas = toInt(mc["replicas"])
+	o.minReplicas = toInt(mc["minReplicas"])
+	o.maxReplicas = toInt(mc["maxReplicas"])
 
 	return nil
 }
@@ -76,6 +77,10 @@ func (o *Options) validate() (err error) {
 		return fmt.Errorf("machinepool name is missing")
 	}
 
+	if o.maxReplicas != 0 || o.minReplicas != 0 {
+		return machinepool.ValidateAutoscaling(int32(o.minReplicas), int32(o.maxReplicas))
+	}
+
 	// // replicas defaults
 	if o.replicas == 0 {
 		return fmt.Errorf("replicas is missing")
@@ -85,32 +90,19 @@ func (o *Options) validate() (err error) {
 }
 
 func (o *Options) run() error {
-	dynamicClient, err := o.CMFlags.KubectlFactory.DynamicClient()
+	target, err := machinepool.NewTarget(o.CMFlags, o.clusterName, o.clusterPoolHost, o.clusterClaim)
 	if err != nil {
 		return err
 	}
-	discoveryClient, err := o.CMFlags.KubectlFactory.ToDiscoveryClient()
-	if err != nil {
-		return err
-	}
-	return o.runWithClient(dynamicClient, discoveryClient)
+	return target.Scale(o.machinePoolName, int64(o.replicas), int32(o.minReplicas), int32(o.maxReplicas), o.CMFlags.DryRun)
 }
 
-func (o *Options) runWithClient(dynamicClient dynamic.Interface,
-	discoveryClient discovery.DiscoveryInterface) (err error) {
-	gvr := schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "machinepools"}
-	dr := dynamicClient.Resource(gvr)
-	mp, err := dr.Namespace(o.clusterName).Get(context.TODO(), o.machinePoolName, metav1.GetOptions{})
-	if err != nil {
-		return
-	}
-	if !o.CMFlags.DryRun {
-		spec := mp.Object["spec"].(map[string]interface{})
-		spec["replicas"] = o.replicas
-		_, err = dr.Namespace(o.clusterName).Update(context.TODO(), mp, metav1.UpdateOptions{})
-		if err != nil {
-			return
-		}
+func toInt(v interface{}) int {
+	switch i := v.(type) {
+	case float64:
+		return int(i)
+	case int:
+		return i
 	}
-	return nil
+	return 0
 }
@@ -3,6 +3,7 @@ package cluster
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -12,7 +13,11 @@ type Options struct {
 	clusterName     string
 	machinePoolName string
 	replicas        int
-	valuesPath      string
+	minReplicas     int
+	maxReplicas     int
+	clusterPoolHost string
+	clusterClaim    bool
+	valuesFlags     helpers.ValuesFlags
 	values          map[string]interface{}
 }
 
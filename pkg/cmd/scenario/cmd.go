@@ -0,0 +1,26 @@
+// Copyright Contributors to the Open Cluster Management project
+package scenario
+
+import (
+	"github.com/stolostron/cm-cli/pkg/cmd/scenario/dump"
+	"github.com/stolostron/cm-cli/pkg/cmd/scenario/list"
+	"github.com/stolostron/cm-cli/pkg/cmd/scenario/show"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the scenario sub-commands
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scenario",
+		Short: "list, display and dump the templates of the scenarios to override them with --scenario-dir",
+	}
+
+	cmd.AddCommand(list.NewCmd(cmFlags, streams))
+	cmd.AddCommand(show.NewCmd(cmFlags, streams))
+	cmd.AddCommand(dump.NewCmd(cmFlags, streams))
+
+	return cmd
+}
@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+package dump
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Copy the embedded templates of the common hub resources of create cluster in the scenario directory to customize them
+%[1]s scenario dump create-cluster create/hub/common --scenario-dir ~/cm-scenarios
+
+# Then the customized templates are used by create cluster
+%[1]s create cluster --values values.yaml --scenario-dir ~/cm-scenarios
+
+# Copy all the templates of a scenario in another directory
+%[1]s scenario dump attach-cluster --output-dir /tmp/scenarios
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "dump <scenario> [<file_or_directory>...]",
+		Short:        "copy the embedded templates of a scenario in the scenario directory",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.outputDir, "output-dir", "", "The directory where the templates are copied in a <scenario> sub-directory, defaults to the scenario directory")
+	cmd.Flags().BoolVar(&o.force, "force", false, "Overwrite the existing files")
+
+	return cmd
+}
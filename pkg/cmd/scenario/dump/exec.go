@@ -0,0 +1,68 @@
+// Copyright Contributors to the Open Cluster Management project
+package dump
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/scenarios"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 1 {
+		return fmt.Errorf("the scenario is missing")
+	}
+	o.scenario = args[0]
+	if len(args) > 1 {
+		o.prefixes = args[1:]
+	}
+	if len(o.outputDir) == 0 {
+		o.outputDir = helpers.GetScenarioDir()
+	}
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	if len(o.outputDir) == 0 {
+		return fmt.Errorf("--output-dir, --scenario-dir or $%s must be set", helpers.ScenarioDirEnv)
+	}
+	_, err = scenarios.Get(o.scenario)
+	return err
+}
+
+func (o *Options) run() (err error) {
+	r, err := scenarios.Get(o.scenario)
+	if err != nil {
+		return err
+	}
+	files, err := r.Builtin.AssetNames(o.prefixes, nil, "")
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no file of the scenario %s matches %v", o.scenario, o.prefixes)
+	}
+	for _, f := range files {
+		path := filepath.Join(o.outputDir, r.Name, filepath.FromSlash(f))
+		if _, err := os.Stat(path); err == nil && !o.force {
+			fmt.Fprintf(o.streams.Out, "%s already exists, skipped\n", path)
+			continue
+		}
+		b, err := r.Builtin.Asset(f)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, b, 0600); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.streams.Out, "%s written\n", path)
+	}
+	return nil
+}
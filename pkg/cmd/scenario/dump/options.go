@@ -0,0 +1,26 @@
+// Copyright Contributors to the Open Cluster Management project
+package dump
+
+import (
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+type Options struct {
+	//CMFlags: The generic optiosn from the cm cli-runtime.
+	CMFlags  *genericclioptionscm.CMFlags
+	scenario string
+	//The files or directories of the scenario to dump, all if empty
+	prefixes  []string
+	outputDir string
+	//Overwrite the existing files
+	force   bool
+	streams genericclioptions.IOStreams
+}
+
+func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CMFlags: cmFlags,
+		streams: streams,
+	}
+}
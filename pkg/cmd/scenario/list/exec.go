@@ -0,0 +1,101 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/scenarios"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	sourceBuiltin    = "builtin"
+	sourceOverridden = "overridden"
+	sourceAdded      = "added"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) > 0 {
+		o.scenario = args[0]
+	}
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	if len(o.scenario) != 0 {
+		_, err = scenarios.Get(o.scenario)
+	}
+	return err
+}
+
+func (o *Options) run() (err error) {
+	if len(o.scenario) != 0 {
+		return o.listFiles()
+	}
+	tw := printers.GetNewTabWriter(o.streams.Out)
+	fmt.Fprintf(tw, "NAME\tFILES\tOVERRIDDEN\tADDED\n")
+	for _, name := range scenarios.Names() {
+		r, err := scenarios.Get(name)
+		if err != nil {
+			return err
+		}
+		files, sources, err := scenarioFiles(r)
+		if err != nil {
+			return err
+		}
+		count := make(map[string]int)
+		for _, f := range files {
+			count[sources[f]]++
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", name, len(files), count[sourceOverridden], count[sourceAdded])
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(helpers.GetScenarioDir()) == 0 {
+		fmt.Fprintf(o.streams.Out, "\nno scenario directory, set --scenario-dir or $%s to override the templates\n", helpers.ScenarioDirEnv)
+	}
+	return nil
+}
+
+func (o *Options) listFiles() error {
+	r, err := scenarios.Get(o.scenario)
+	if err != nil {
+		return err
+	}
+	files, sources, err := scenarioFiles(r)
+	if err != nil {
+		return err
+	}
+	tw := printers.GetNewTabWriter(o.streams.Out)
+	fmt.Fprintf(tw, "FILE\tSOURCE\n")
+	for _, f := range files {
+		fmt.Fprintf(tw, "%s\t%s\n", f, sources[f])
+	}
+	return tw.Flush()
+}
+
+// scenarioFiles returns the files of the scenario, embedded ones first, and where each is read from
+func scenarioFiles(r *helpers.ScenarioReader) ([]string, map[string]string, error) {
+	builtin, err := r.Builtin.AssetNames(nil, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := r.AssetNames(nil, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	sources := make(map[string]string, len(files))
+	for _, f := range files {
+		sources[f] = sourceAdded
+	}
+	for _, f := range builtin {
+		sources[f] = sourceBuiltin
+		if r.Overridden(f) {
+			sources[f] = sourceOverridden
+		}
+	}
+	return files, sources, nil
+}
@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"fmt"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Display a template of a scenario, the one of the scenario directory if overridden
+%[1]s scenario show create-cluster create/hub/common/cluster_deployment_cr.yaml
+
+# Display the embedded version of an overridden template
+%[1]s scenario show create-cluster create/hub/common/cluster_deployment_cr.yaml --builtin
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "show <scenario> <file>",
+		Short:        "display a template of a scenario",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.builtin, "builtin", false, "Display the embedded template even if it is overridden in the scenario directory")
+
+	return cmd
+}
@@ -0,0 +1,41 @@
+// Copyright Contributors to the Open Cluster Management project
+package show
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stolostron/cm-cli/pkg/scenarios"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	if len(args) < 2 {
+		return fmt.Errorf("the scenario and the file are required")
+	}
+	o.scenario = args[0]
+	o.file = args[1]
+	return nil
+}
+
+func (o *Options) validate() (err error) {
+	_, err = scenarios.Get(o.scenario)
+	return err
+}
+
+func (o *Options) run() (err error) {
+	r, err := scenarios.Get(o.scenario)
+	if err != nil {
+		return err
+	}
+	var b []byte
+	if o.builtin {
+		b, err = r.Builtin.Asset(o.file)
+	} else {
+		b, err = r.Asset(o.file)
+	}
+	if err != nil {
+		return fmt.Errorf("file %s not found in the scenario %s, run 'scenario list %s' to list the files", o.file, o.scenario, o.scenario)
+	}
+	_, err = o.streams.Out.Write(b)
+	return err
+}
@@ -17,6 +17,15 @@ import (
 var example = `
 # set clusters
 %[1]s set clusterclaim <clusterclaim_name>[,<clusterclaim_name>...] <options>
+
+# share clusterclaims with a user and a group
+%[1]s set clusterclaim <clusterclaim_name>[,<clusterclaim_name>...] --add-user <user> --add-group <group>
+
+# revoke the access of a user
+%[1]s set clusterclaim <clusterclaim_name> --remove-subject User:<user>
+
+# transfer a clusterclaim to another user
+%[1]s set clusterclaim <clusterclaim_name> --owner <user>
 `
 
 // NewCmd ...
@@ -41,6 +50,10 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd.Flags().StringVar(&o.ClusterPoolHost, "cph", "", "The clusterpoolhost to use")
 	cmd.Flags().BoolVar(&o.HibernateScheduleOn, "hibernate-schedule-on", false, "Set the hibernation schedule to on")
 	cmd.Flags().BoolVar(&o.HibernateScheduleOff, "hibernate-schedule-off", false, "Set the hibernation schedule to off")
+	cmd.Flags().StringSliceVar(&o.SubjectsChange.AddUsers, "add-user", []string{}, "Give access to the clusterclaims to these users")
+	cmd.Flags().StringSliceVar(&o.SubjectsChange.AddGroups, "add-group", []string{}, "Give access to the clusterclaims to these groups")
+	cmd.Flags().StringSliceVar(&o.SubjectsChange.RemoveSubjects, "remove-subject", []string{}, "Revoke the access of these subjects, formatted as [<kind>:]<name>")
+	cmd.Flags().StringVar(&o.SubjectsChange.Owner, "owner", "", "Transfer the clusterclaims to this user, all other User and ServiceAccount subjects are removed")
 
 	return cmd
 }
@@ -9,18 +9,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var scheduleSkip string
-
 func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 	if len(args) < 1 {
 		return fmt.Errorf("cluster names are missing")
 	}
 	o.ClusterClaims = args[0]
 	if cmd.Flags().Lookup("hibernate-schedule-on").Changed {
-		scheduleSkip = "true"
+		o.scheduleSkip = "true"
 	}
 	if cmd.Flags().Lookup("hibernate-schedule-off").Changed {
-		scheduleSkip = "skip"
+		o.scheduleSkip = "skip"
 	}
 
 	return nil
@@ -41,5 +39,14 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
-	return cph.SetHibernateScheduleClusterClaims(o.ClusterClaims, scheduleSkip, o.CMFlags.DryRun)
+	if !o.SubjectsChange.IsEmpty() {
+		if err := cph.SetClusterClaimsSubjects(o.ClusterClaims, o.SubjectsChange, o.CMFlags.DryRun); err != nil {
+			return err
+		}
+	}
+
+	if len(o.scheduleSkip) == 0 {
+		return nil
+	}
+	return cph.SetHibernateScheduleClusterClaims(o.ClusterClaims, o.scheduleSkip, o.CMFlags.DryRun)
 }
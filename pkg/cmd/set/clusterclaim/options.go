@@ -2,6 +2,7 @@
 package clusterclaim
 
 import (
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
@@ -16,10 +17,15 @@ type Options struct {
 	HibernateScheduleOn bool
 	// hibernate schedule off
 	HibernateScheduleOff bool
+	// the hibernate label set by the hibernate schedule flags, empty if none is set
+	scheduleSkip string
+	// the changes to apply on the clusterclaim subjects
+	SubjectsChange *clusterpoolhost.ClusterClaimSubjectsChange
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
 	return &Options{
-		CMFlags: cmFlags,
+		CMFlags:        cmFlags,
+		SubjectsChange: &clusterpoolhost.ClusterClaimSubjectsChange{},
 	}
 }
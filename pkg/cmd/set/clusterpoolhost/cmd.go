@@ -16,6 +16,12 @@ import (
 var example = `
 # Use a clusterpoolhost
 %[1]s set cph clusterpoolhost
+
+# Set the hooks run by hibernate cc and run cc on a clusterpoolhost
+%[1]s set cph clusterpoolhost --pre-hibernate-hook ./drain.sh --post-run-hook ./approve-csrs.sh
+
+# Remove the hooks of a clusterpoolhost
+%[1]s set cph clusterpoolhost --clear-hooks
 `
 
 // NewCmd provides a cobra command to use a clusterpoolhost
@@ -24,7 +30,7 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 	cmd := &cobra.Command{
 		Use:          "clusterpoolhost",
 		Aliases:      []string{"cph"},
-		Short:        "set cph makes the given clusterpoolhost active/current",
+		Short:        "set cph makes the given clusterpoolhost active/current or sets its hooks",
 		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRunE: func(c *cobra.Command, args []string) error {
@@ -38,5 +44,11 @@ func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOSt
 		},
 	}
 
+	cmd.Flags().StringArrayVar(&o.Hooks.PreHibernate, "pre-hibernate-hook", nil, "Script or manifests file or directory to run before hibernating a clusterclaim")
+	cmd.Flags().StringArrayVar(&o.Hooks.PostHibernate, "post-hibernate-hook", nil, "Script to run once a clusterclaim is hibernated")
+	cmd.Flags().StringArrayVar(&o.Hooks.PreRun, "pre-run-hook", nil, "Script to run before resuming a clusterclaim")
+	cmd.Flags().StringArrayVar(&o.Hooks.PostRun, "post-run-hook", nil, "Script or manifests file or directory to run once a clusterclaim is running")
+	cmd.Flags().BoolVar(&o.ClearHooks, "clear-hooks", false, "Remove the hooks of the clusterpoolhost, the hooks set with the other flags are kept")
+
 	return cmd
 }
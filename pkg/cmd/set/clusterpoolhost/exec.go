@@ -13,6 +13,7 @@ func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("clusterpoolcph name is missing")
 	}
 	o.ClusterPoolHost = args[0]
+	o.setHooks = o.ClearHooks || !o.Hooks.IsEmpty()
 	return nil
 }
 
@@ -31,5 +32,19 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
+	if o.setHooks {
+		if o.ClearHooks {
+			cph.Hooks = nil
+		}
+		cph.Hooks = cph.Hooks.Override(&o.Hooks)
+		if cph.Hooks.IsEmpty() {
+			cph.Hooks = nil
+		}
+		if o.CMFlags.DryRun {
+			return nil
+		}
+		return cphs.ApplyClusterPoolHosts()
+	}
+
 	return cphs.SetActive(cph)
 }
@@ -3,6 +3,7 @@ package clusterpoolhost
 
 import (
 	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -10,6 +11,12 @@ type Options struct {
 	//CMFlags: The generic optiosn from the cm cli-runtime.
 	CMFlags         *genericclioptionscm.CMFlags
 	ClusterPoolHost string
+	//The hooks run around the hibernation and the resume of the clusterclaims
+	Hooks helpers.Hooks
+	//Remove all hooks before setting the new ones
+	ClearHooks bool
+	//true if the hooks must be updated instead of activating the clusterpoolhost
+	setHooks bool
 }
 
 func newOptions(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *Options {
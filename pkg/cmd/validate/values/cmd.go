@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+package values
+
+import (
+	"fmt"
+	"strings"
+
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"github.com/stolostron/cm-cli/pkg/valuesschemas"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var example = `
+# Validate a values file for 'create cluster'
+%[1]s validate values --for create-cluster -f values.yaml
+
+# Validate a values file from stdin for 'create clusterpool'
+cat values.yaml | %[1]s validate values --for create-clusterpool
+`
+
+// NewCmd ...
+func NewCmd(cmFlags *genericclioptionscm.CMFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(cmFlags, streams)
+	cmd := &cobra.Command{
+		Use:          "values",
+		Short:        "Validate a values file against the schema of a command",
+		Long:         "Validate a values file against the schema of a command, the same validation is done by the command itself before applying anything",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.complete(cmd, args))
+			cmdutil.CheckErr(o.validate())
+			cmdutil.CheckErr(o.run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.For, "for", "", fmt.Sprintf("The command using the values (%s)", strings.Join(valuesschemas.Names(), ", ")))
+	o.valuesFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
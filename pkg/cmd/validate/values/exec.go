@@ -0,0 +1,42 @@
+// Copyright Contributors to the Open Cluster Management project
+package values
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stolostron/cm-cli/pkg/valuesschemas"
+
+	"github.com/spf13/cobra"
+)
+
+func (o *Options) complete(cmd *cobra.Command, args []string) (err error) {
+	o.For = valuesschemas.Name(o.For)
+	o.values, err = o.valuesFlags.ToValuesMap()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if len(o.For) == 0 {
+		return fmt.Errorf("--for is missing")
+	}
+	if _, ok := valuesschemas.Get(o.For); !ok {
+		return fmt.Errorf("--for must be one of %s and got %s", strings.Join(valuesschemas.Names(), ", "), o.For)
+	}
+	if len(o.values) == 0 {
+		return fmt.Errorf("values are missing")
+	}
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	s, _ := valuesschemas.Get(o.For)
+	if err := s.Validate(o.values); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.streams.Out, "values are valid for %s\n", o.For)
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright Contributors to the Open Cluster Management project
+package values
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// fixtures are the values files of the unit and functional tests of the commands
+var fixtures = map[string][]string{
+	"attach-cluster": {
+		"../../attach/cluster/test/unit/values-with-data.yaml",
+		"../../../../test/functional/attach/cluster/*_values.yaml",
+		"../../../../test/functional/attach/cluster/*_values_with_labels.yaml",
+	},
+	"create-cluster": {
+		"../../create/cluster/test/unit/values-fake-*.yaml",
+		"../../../../test/functional/create/cluster/*_values.yaml",
+		"../../../../test/functional/create/cluster/*_values_with_labels.yaml",
+	},
+	"enable-addons": {
+		"../../enable/addons/test/unit/values-with-data.yaml",
+	},
+}
+
+func TestOptions_runFixtures(t *testing.T) {
+	for name, patterns := range fixtures {
+		for _, pattern := range patterns {
+			paths, err := filepath.Glob(pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(paths) == 0 {
+				t.Fatalf("no values file matches %s", pattern)
+			}
+			for _, path := range paths {
+				t.Run(name+"/"+filepath.Base(path), func(t *testing.T) {
+					o := &Options{
+						For:         name,
+						valuesFlags: helpers.ValuesFlags{Paths: []string{path}},
+						streams:     genericclioptions.NewTestIOStreamsDiscard(),
+					}
+					if err := o.complete(nil, nil); err != nil {
+						t.Fatal(err)
+					}
+					if err := o.validate(); err != nil {
+						t.Fatal(err)
+					}
+					if err := o.run(); err != nil {
+						t.Errorf("the values file %s is not valid: %v", path, err)
+					}
+				})
+			}
+		}
+	}
+}
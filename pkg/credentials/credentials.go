@@ -0,0 +1,334 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	AWS       = "aws"
+	AZURE     = "azure"
+	GCP       = "gcp"
+	OPENSTACK = "openstack"
+	VSPHERE   = "vsphere"
+)
+
+const (
+	// CredentialsLabel marks a secret as the credentials of a cloud provider
+	CredentialsLabel = "cluster.open-cluster-management.io/credentials"
+	// TypeLabel is the cloud provider of the credentials
+	TypeLabel = "cluster.open-cluster-management.io/type"
+	// CopiedFromNamespaceLabel and CopiedFromSecretNameLabel mark the clusterpool and
+	// clusterdeployment secrets copied from a credentials secret
+	CopiedFromNamespaceLabel  = "cluster.open-cluster-management.io/copiedFromNamespace"
+	CopiedFromSecretNameLabel = "cluster.open-cluster-management.io/copiedFromSecretName"
+)
+
+var secretTemplatePath = filepath.Join("create", "credentials", "credentials_secret_cr.yaml")
+
+// Clouds are the supported cloud providers
+var Clouds = []string{AWS, AZURE, GCP, OPENSTACK, VSPHERE}
+
+// field maps a key of the credentials secret to a value of the cloud section of the values
+type field struct {
+	key   string
+	value string
+}
+
+var fields = map[string][]field{
+	AWS: {
+		{key: "aws_access_key_id", value: "awsAccessKeyID"},
+		{key: "aws_secret_access_key", value: "awsSecretAccessKeyID"},
+	},
+	AZURE: {
+		{key: "baseDomainResourceGroupName", value: "baseDomainRGN"},
+	},
+	GCP: {
+		{key: "osServiceAccount.json", value: "osServiceAccountJson"},
+		{key: "projectID", value: "projectID"},
+	},
+	OPENSTACK: {
+		{key: "clouds.yaml", value: "cloudsYaml"},
+		{key: "cloud", value: "cloud"},
+	},
+	VSPHERE: {
+		{key: "username", value: "username"},
+		{key: "password", value: "password"},
+		{key: "vCenter", value: "vcenter"},
+		{key: "cacertificate", value: "cacertificate"},
+		{key: "cluster", value: "cluster"},
+		{key: "datacenter", value: "datacenter"},
+		{key: "defaultDatastore", value: "datastore"},
+	},
+}
+
+// hiveKeys are the keys of the credentials secret used by hive in the clusterpool and clusterdeployment secrets
+var hiveKeys = map[string][]string{
+	AWS:       {"aws_access_key_id", "aws_secret_access_key"},
+	AZURE:     {"osServicePrincipal.json"},
+	GCP:       {"osServiceAccount.json"},
+	OPENSTACK: {"clouds.yaml", "cloud"},
+	VSPHERE:   {"username", "password"},
+}
+
+type servicePrincipal struct {
+	ClientID       string `json:"clientId"`
+	ClientSecret   string `json:"clientSecret"`
+	TenantID       string `json:"tenantId"`
+	SubscriptionID string `json:"subscriptionId"`
+}
+
+// IsSupported returns true if the cloud provider is supported
+func IsSupported(cloud string) bool {
+	for _, c := range Clouds {
+		if c == cloud {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRef splits a [<namespace>/]<name> reference, the namespace defaults to defaultNamespace
+func ParseRef(ref, defaultNamespace string) (namespace, name string) {
+	if i := strings.Index(ref, "/"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return defaultNamespace, ref
+}
+
+// RefFromValues returns the [<namespace>/]<name> reference of the credentials set in the values, as written by --interactive
+func RefFromValues(values map[string]interface{}) string {
+	name, err := helpers.NestedString(values, "credentials.name")
+	if err != nil {
+		return ""
+	}
+	if namespace, err := helpers.NestedString(values, "credentials.namespace"); err == nil {
+		return namespace + "/" + name
+	}
+	return name
+}
+
+// Target is the cluster hosting the credentials, the hub or a clusterpoolhost
+type Target struct {
+	RestConfig *rest.Config
+	// Namespace of the credentials
+	Namespace string
+	// Scope is the namespace of the secrets copied from the credentials, empty for all namespaces
+	Scope string
+}
+
+// NewTarget returns the clusterpoolhost clusterPoolHostName if set, otherwise the hub.
+// The credentials are in the namespace of the clusterpoolhost or in the current namespace of the hub.
+func NewTarget(cmFlags *genericclioptionscm.CMFlags, clusterPoolHostName string) (*Target, error) {
+	if len(clusterPoolHostName) == 0 {
+		restConfig, err := cmFlags.KubectlFactory.ToRESTConfig()
+		if err != nil {
+			return nil, err
+		}
+		namespace, _, err := cmFlags.KubectlFactory.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, err
+		}
+		return &Target{RestConfig: restConfig, Namespace: namespace}, nil
+	}
+	cph, err := clusterpoolhost.GetClusterPoolHost(clusterPoolHostName)
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &Target{RestConfig: restConfig, Namespace: cph.Namespace, Scope: cph.Namespace}, nil
+}
+
+// Render returns the credentials secret described by the credentials section of the values
+func Render(values map[string]interface{}) (*corev1.Secret, error) {
+	applier := apply.NewApplierBuilder().Build()
+	b, err := applier.MustTemplateAsset(scenario.GetScenarioResourcesReader(), values, "", secretTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := yaml.Unmarshal(b, secret); err != nil {
+		return nil, err
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	for k, v := range secret.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret.StringData = nil
+	return secret, nil
+}
+
+// Apply creates or updates the credentials secret described by the credentials section of the values
+func (t *Target) Apply(values map[string]interface{}, cmFlags *genericclioptionscm.CMFlags, outputFile string) error {
+	reader := scenario.GetScenarioResourcesReader()
+	applierBuilder := apply.NewApplierBuilder().WithRestConfig(t.RestConfig)
+	output, err := applierBuilder.Build().ApplyDirectly(reader, values, cmFlags.DryRun, "", secretTemplatePath)
+	if err != nil {
+		return err
+	}
+	return helpers.WriteOutput(cmFlags, applierBuilder, outputFile, output)
+}
+
+// Get returns the credentials secret, an error is returned if the secret is not labelled as credentials
+func Get(kubeClient kubernetes.Interface, namespace, name string) (*corev1.Secret, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := secret.Labels[CredentialsLabel]; !ok {
+		return nil, fmt.Errorf("secret %s/%s is not labelled %s", namespace, name, CredentialsLabel)
+	}
+	return secret, nil
+}
+
+// Choices lists for the --interactive mode the credentials in namespace for the cloud set at cloudPath in the values
+func Choices(kubeClient kubernetes.Interface, namespace, cloudPath string) helpers.ChoicesFunc {
+	return func(values map[string]interface{}) ([]string, error) {
+		cloud, err := helpers.NestedString(values, cloudPath)
+		if err != nil {
+			return nil, err
+		}
+		l, err := kubeClient.CoreV1().Secrets(namespace).List(context.TODO(), metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s,%s=%s", CredentialsLabel, TypeLabel, cloud),
+		})
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(l.Items))
+		for _, s := range l.Items {
+			names = append(names, s.Name)
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+}
+
+// Cloud returns the cloud provider of a credentials secret
+func Cloud(secret *corev1.Secret) string {
+	return secret.Labels[TypeLabel]
+}
+
+// SetValues copies the credentials of the secret in the cloud section of values[section]
+// (ie: managedCluster or clusterPool) and records the secret in values["credentials"]
+// so the generated secret is labelled as copied from it.
+func SetValues(secret *corev1.Secret, cloud, section string, values map[string]interface{}) error {
+	if Cloud(secret) != cloud {
+		return fmt.Errorf("credentials %s/%s are for %s and the cloud is %s", secret.Namespace, secret.Name, Cloud(secret), cloud)
+	}
+	isection, ok := values[section]
+	if !ok || isection == nil {
+		return fmt.Errorf("%s is missing", section)
+	}
+	s, ok := isection.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s is not a map", section)
+	}
+	c, ok := s[cloud].(map[string]interface{})
+	if !ok {
+		c = make(map[string]interface{})
+		s[cloud] = c
+	}
+	for _, f := range fields[cloud] {
+		if v, ok := secret.Data[f.key]; ok {
+			c[f.value] = string(v)
+		}
+	}
+	if v, ok := secret.Data["baseDomain"]; ok && len(v) != 0 {
+		c["baseDnsDomain"] = string(v)
+	}
+	if cloud == AZURE {
+		sp := &servicePrincipal{}
+		if err := json.Unmarshal(secret.Data["osServicePrincipal.json"], sp); err != nil {
+			return fmt.Errorf("unable to parse osServicePrincipal.json of %s/%s: %v", secret.Namespace, secret.Name, err)
+		}
+		c["clientID"] = sp.ClientID
+		c["clientSecret"] = sp.ClientSecret
+		c["tenantID"] = sp.TenantID
+		c["subscriptionID"] = sp.SubscriptionID
+	}
+	values["credentials"] = map[string]interface{}{
+		"name":      secret.Name,
+		"namespace": secret.Namespace,
+	}
+	return nil
+}
+
+// Rotate copies the credentials of the secret into the clusterpool and clusterdeployment secrets
+// copied from it in namespace, in all namespaces if namespace is empty.
+// It returns the <namespace>/<name> of the updated secrets.
+func Rotate(kubeClient kubernetes.Interface, secret *corev1.Secret, namespace string, dryRun bool) ([]string, error) {
+	cloud := Cloud(secret)
+	keys, ok := hiveKeys[cloud]
+	if !ok {
+		return nil, fmt.Errorf("credentials %s/%s have an unsupported cloud %s", secret.Namespace, secret.Name, cloud)
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		CopiedFromNamespaceLabel:  secret.Namespace,
+		CopiedFromSecretNameLabel: secret.Name,
+	})
+	copies, err := kubeClient.CoreV1().Secrets(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(copies.Items, func(i, j int) bool {
+		if copies.Items[i].Namespace != copies.Items[j].Namespace {
+			return copies.Items[i].Namespace < copies.Items[j].Namespace
+		}
+		return copies.Items[i].Name < copies.Items[j].Name
+	})
+	updated := make([]string, 0)
+	for i := range copies.Items {
+		c := &copies.Items[i]
+		if !copyKeys(secret, c, keys) {
+			continue
+		}
+		if !dryRun {
+			if _, err := kubeClient.CoreV1().Secrets(c.Namespace).Update(context.TODO(), c, metav1.UpdateOptions{}); err != nil {
+				return updated, err
+			}
+		}
+		updated = append(updated, fmt.Sprintf("%s/%s", c.Namespace, c.Name))
+	}
+	return updated, nil
+}
+
+// copyKeys copies the keys from the credentials to the copy and returns true if the copy changed
+func copyKeys(from, to *corev1.Secret, keys []string) bool {
+	changed := false
+	if to.Data == nil {
+		to.Data = make(map[string][]byte)
+	}
+	for _, k := range keys {
+		v, ok := from.Data[k]
+		if !ok || string(to.Data[k]) == string(v) {
+			continue
+		}
+		to.Data[k] = v
+		changed = true
+	}
+	return changed
+}
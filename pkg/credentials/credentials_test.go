@@ -0,0 +1,147 @@
+// Copyright Contributors to the Open Cluster Management project
+package credentials
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRender(t *testing.T) {
+	values := map[string]interface{}{
+		"credentials": map[string]interface{}{
+			"name":          "mycreds",
+			"namespace":     "default",
+			"cloud":         "azure",
+			"baseDnsDomain": "example.com",
+			"azure": map[string]interface{}{
+				"clientID":       "id",
+				"clientSecret":   "secret",
+				"tenantID":       "tenant",
+				"subscriptionID": "subscription",
+				"baseDomainRGN":  "rgn",
+			},
+		},
+	}
+	secret, err := Render(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Name != "mycreds" || secret.Namespace != "default" {
+		t.Errorf("unexpected metadata %s/%s", secret.Namespace, secret.Name)
+	}
+	if _, ok := secret.Labels[CredentialsLabel]; !ok || Cloud(secret) != AZURE {
+		t.Errorf("unexpected labels %v", secret.Labels)
+	}
+	if string(secret.Data["baseDomain"]) != "example.com" || string(secret.Data["baseDomainResourceGroupName"]) != "rgn" {
+		t.Errorf("unexpected data %v", secret.Data)
+	}
+
+	// the rendered secret must give back the values
+	cluster := map[string]interface{}{
+		"managedCluster": map[string]interface{}{},
+	}
+	if err := SetValues(secret, AZURE, "managedCluster", cluster); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"clientID":       "id",
+		"clientSecret":   "secret",
+		"tenantID":       "tenant",
+		"subscriptionID": "subscription",
+		"baseDomainRGN":  "rgn",
+		"baseDnsDomain":  "example.com",
+	}
+	if got := cluster["managedCluster"].(map[string]interface{})["azure"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("SetValues() = %v, want %v", got, want)
+	}
+	if got := cluster["credentials"]; !reflect.DeepEqual(got, map[string]interface{}{"name": "mycreds", "namespace": "default"}) {
+		t.Errorf("unexpected credentials reference %v", got)
+	}
+}
+
+func TestSetValuesWrongCloud(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycreds", Labels: map[string]string{TypeLabel: AWS}},
+	}
+	values := map[string]interface{}{
+		"clusterPool": map[string]interface{}{},
+	}
+	if err := SetValues(secret, GCP, "clusterPool", values); err == nil {
+		t.Error("expected an error when the cloud of the credentials differs")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	copiedLabels := map[string]string{
+		CopiedFromNamespaceLabel:  "default",
+		CopiedFromSecretNameLabel: "mycreds",
+	}
+	creds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mycreds",
+			Namespace: "default",
+			Labels:    map[string]string{CredentialsLabel: "", TypeLabel: AWS},
+		},
+		Data: map[string][]byte{
+			"aws_access_key_id":     []byte("newid"),
+			"aws_secret_access_key": []byte("newkey"),
+			"baseDomain":            []byte("example.com"),
+		},
+	}
+	objs := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1-creds", Namespace: "cluster1", Labels: copiedLabels},
+			Data: map[string][]byte{
+				"aws_access_key_id":     []byte("oldid"),
+				"aws_secret_access_key": []byte("oldkey"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster2-creds", Namespace: "cluster2", Labels: copiedLabels},
+			Data: map[string][]byte{
+				"aws_access_key_id":     []byte("newid"),
+				"aws_secret_access_key": []byte("newkey"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster3-creds", Namespace: "cluster3"},
+			Data: map[string][]byte{
+				"aws_access_key_id": []byte("otherid"),
+			},
+		},
+	}
+
+	kubeClient := kubefake.NewSimpleClientset(objs...)
+	updated, err := Rotate(kubeClient, creds, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(updated, []string{"cluster1/cluster1-creds"}) {
+		t.Errorf("Rotate() dry-run = %v", updated)
+	}
+	s, _ := kubeClient.CoreV1().Secrets("cluster1").Get(context.TODO(), "cluster1-creds", metav1.GetOptions{})
+	if string(s.Data["aws_access_key_id"]) != "oldid" {
+		t.Error("the secret was updated in dry-run")
+	}
+
+	if _, err := Rotate(kubeClient, creds, "", false); err != nil {
+		t.Fatal(err)
+	}
+	s, _ = kubeClient.CoreV1().Secrets("cluster1").Get(context.TODO(), "cluster1-creds", metav1.GetOptions{})
+	if string(s.Data["aws_access_key_id"]) != "newid" || string(s.Data["aws_secret_access_key"]) != "newkey" {
+		t.Errorf("the secret was not updated %v", s.Data)
+	}
+	if _, ok := s.Data["baseDomain"]; ok {
+		t.Error("only the hive keys must be copied")
+	}
+	s, _ = kubeClient.CoreV1().Secrets("cluster3").Get(context.TODO(), "cluster3-creds", metav1.GetOptions{})
+	if string(s.Data["aws_access_key_id"]) != "otherid" {
+		t.Error("a secret not copied from the credentials was updated")
+	}
+}
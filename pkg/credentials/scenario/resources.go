@@ -0,0 +1,15 @@
+// Copyright Contributors to the Open Cluster Management project
+package scenario
+
+import (
+	"embed"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+)
+
+//go:embed create
+var files embed.FS
+
+func GetScenarioResourcesReader() *helpers.ScenarioReader {
+	return helpers.NewScenarioReader("credentials", &files)
+}
@@ -2,6 +2,8 @@
 package genericclioptions
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
@@ -10,12 +12,20 @@ type CMFlags struct {
 	KubectlFactory cmdutil.Factory
 	//if set the resources will be sent to stdout instead of being applied
 	DryRun bool
+	//if set the resources are also submitted as server-side dry-run requests, DryRun is set too
+	DryRunServer bool
+	//if set a diff between the server-side dry-run results and the live resources is displayed, DryRun and DryRunServer are set too
+	Diff bool
 	//Accept beta cmd
 	Beta bool
 	//Namespace where the server (RHACM/MCE) is installed)
 	ServerNamespace string
 	//if set cm will not check if the server (RHACM/MCE) is installed
 	SkipServerCheck bool
+	//Webhook URLs or local commands notified when a wait completes or fails
+	Notify []string
+	//Directory of the user templates overlaying the embedded scenarios
+	ScenarioDir string
 }
 
 // NewClusteradmFlags returns CMFlags with default values set
@@ -26,8 +36,92 @@ func NewCMFlags(f cmdutil.Factory) *CMFlags {
 }
 
 func (f *CMFlags) AddFlags(flags *pflag.FlagSet) {
-	flags.BoolVar(&f.DryRun, "dry-run", false, "If set the generated resources will be displayed but not applied")
+	dryRun := flags.VarPF(&dryRunValue{f: f}, "dry-run", "",
+		"If set the generated resources will be displayed but not applied, with --dry-run=server they are also submitted to the server as dry-run requests")
+	dryRun.NoOptDefVal = "client"
 	flags.BoolVar(&f.Beta, "beta", false, "If set commands or functionalities in beta version will be available")
 	flags.StringVar(&f.ServerNamespace, "server-namespace", "", "The namespace where the server (RHACM/MCE) is installed")
 	flags.BoolVar(&f.SkipServerCheck, "skip-server-check", false, "If set commands will not check the installed server (RHACM/MCE) target")
+	flags.StringArrayVar(&f.Notify, "notify", []string{},
+		"Webhook URL (http:// or https://) receiving a JSON payload or local command run when a wait completes or fails, can be repeated")
+	flags.StringVar(&f.ScenarioDir, "scenario-dir", "",
+		"Directory of templates, in <scenario>/<file> sub-directories, replacing or completing the embedded scenarios, defaults to $CM_SCENARIO_DIR")
+}
+
+// AddServerDryRunFlags adds --diff to the commands submitting their generated resources
+// as server-side dry-run requests, the other commands reject --dry-run=server
+func (f *CMFlags) AddServerDryRunFlags(flags *pflag.FlagSet) {
+	diff := flags.VarPF(&diffValue{f: f}, "diff", "",
+		"If set the generated resources are submitted to the server as dry-run requests and the differences with the live resources are displayed, nothing is applied")
+	diff.NoOptDefVal = "true"
+}
+
+// ValidateServerDryRun returns an error if --dry-run=server is set on a command without AddServerDryRunFlags
+func (f *CMFlags) ValidateServerDryRun(flags *pflag.FlagSet) error {
+	if f.DryRunServer && flags.Lookup("diff") == nil {
+		return fmt.Errorf("--dry-run=server is not supported by this command, use --dry-run")
+	}
+	return nil
+}
+
+// dryRunValue is the --dry-run option: none (or false), client (or true) and server
+type dryRunValue struct {
+	f *CMFlags
+}
+
+func (v *dryRunValue) String() string {
+	switch {
+	case v.f.DryRunServer:
+		return "server"
+	case v.f.DryRun:
+		return "client"
+	}
+	return "none"
+}
+
+func (v *dryRunValue) Set(s string) error {
+	switch s {
+	case "none", "false":
+		v.f.DryRun, v.f.DryRunServer = false, false
+	case "client", "true":
+		v.f.DryRun, v.f.DryRunServer = true, false
+	case "server":
+		v.f.DryRun, v.f.DryRunServer = true, true
+	default:
+		return fmt.Errorf("must be none, client or server and got %s", s)
+	}
+	//--diff requires the server dry-run whatever the order of the flags
+	if v.f.Diff {
+		v.f.DryRun, v.f.DryRunServer = true, true
+	}
+	return nil
+}
+
+func (v *dryRunValue) Type() string {
+	return "string"
+}
+
+// diffValue is the --diff option, it implies --dry-run=server even if --dry-run is set after it
+type diffValue struct {
+	f *CMFlags
+}
+
+func (v *diffValue) String() string {
+	return fmt.Sprintf("%t", v.f.Diff)
+}
+
+func (v *diffValue) Set(s string) error {
+	switch s {
+	case "true":
+		v.f.Diff, v.f.DryRun, v.f.DryRunServer = true, true, true
+	case "false":
+		v.f.Diff = false
+	default:
+		return fmt.Errorf("must be true or false and got %s", s)
+	}
+	return nil
+}
+
+func (v *diffValue) Type() string {
+	return "bool"
 }
@@ -0,0 +1,66 @@
+// Copyright Contributors to the Open Cluster Management project
+package genericclioptions
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestCMFlags_DiffAndDryRun(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		wantDryRun       bool
+		wantDryRunServer bool
+		wantDiff         bool
+	}{
+		{name: "dry-run", args: []string{"--dry-run"}, wantDryRun: true},
+		{name: "dry-run server", args: []string{"--dry-run=server"}, wantDryRun: true, wantDryRunServer: true},
+		{name: "diff", args: []string{"--diff"}, wantDryRun: true, wantDryRunServer: true, wantDiff: true},
+		{name: "diff then dry-run", args: []string{"--diff", "--dry-run"}, wantDryRun: true, wantDryRunServer: true, wantDiff: true},
+		{name: "dry-run then diff", args: []string{"--dry-run", "--diff"}, wantDryRun: true, wantDryRunServer: true, wantDiff: true},
+		{name: "diff then dry-run none", args: []string{"--diff", "--dry-run=none"}, wantDryRun: true, wantDryRunServer: true, wantDiff: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewCMFlags(nil)
+			flags := pflag.NewFlagSet(tt.name, pflag.ContinueOnError)
+			f.AddFlags(flags)
+			f.AddServerDryRunFlags(flags)
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if f.DryRun != tt.wantDryRun || f.DryRunServer != tt.wantDryRunServer || f.Diff != tt.wantDiff {
+				t.Errorf("got DryRun=%t DryRunServer=%t Diff=%t, want DryRun=%t DryRunServer=%t Diff=%t",
+					f.DryRun, f.DryRunServer, f.Diff, tt.wantDryRun, tt.wantDryRunServer, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestCMFlags_ValidateServerDryRun(t *testing.T) {
+	f := NewCMFlags(nil)
+	flags := pflag.NewFlagSet("hibernate", pflag.ContinueOnError)
+	f.AddFlags(flags)
+	if err := flags.Parse([]string{"--dry-run=server"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ValidateServerDryRun(flags); err == nil {
+		t.Error("expected an error for --dry-run=server on a command without --diff")
+	}
+	if err := flags.Parse([]string{"--dry-run"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ValidateServerDryRun(flags); err != nil {
+		t.Errorf("--dry-run must be accepted, got %v", err)
+	}
+
+	f.AddServerDryRunFlags(flags)
+	if err := flags.Parse([]string{"--dry-run=server"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ValidateServerDryRun(flags); err != nil {
+		t.Errorf("--dry-run=server must be accepted with --diff, got %v", err)
+	}
+}
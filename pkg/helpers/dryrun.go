@@ -0,0 +1,224 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// WriteOutput writes the resources generated by a command in the output file and,
+// on --dry-run=server or --diff, submits them to the server with the clients of the applier
+func WriteOutput(cmFlags *genericclioptions.CMFlags, applierBuilder *apply.ApplierBuilder, outputFile string, output []string) error {
+	if err := apply.WriteOutput(outputFile, output); err != nil {
+		return err
+	}
+	if !cmFlags.DryRunServer {
+		return nil
+	}
+	return ServerDryRun(applierBuilder.GetKubeClient(), applierBuilder.GetDynamicClient(), output, cmFlags.Diff, os.Stdout)
+}
+
+// ServerDryRun submits the resources rendered by a dry-run as server-side dry-run requests, created or updated
+// the way the applier does, then writes the objects returned by the server or, if diff is set,
+// a unified diff against the live objects. The values of the secrets are masked.
+// The rejections of the server (ie: immutable fields, admission webhooks) are reported per resource.
+func ServerDryRun(kubeClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	resources []string,
+	diff bool,
+	out io.Writer) error {
+	objects, err := splitResources(resources)
+	if err != nil {
+		return err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClient.Discovery()))
+	createdNamespaces := make(map[string]bool)
+	rejected := make([]string, 0)
+	changed := 0
+	for _, required := range objects {
+		id := resourceID(required)
+		live, result, err := dryRunResource(mapper, dynamicClient, required, createdNamespaces)
+		if err != nil {
+			rejected = append(rejected, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		if live == nil && required.GetKind() == "Namespace" {
+			createdNamespaces[required.GetName()] = true
+		}
+		if result == required {
+			fmt.Fprintf(out, "# %s is not validated by the server, its namespace is created by this command\n", id)
+		}
+		if !diff {
+			o := cleanObject(result)
+			if result.GetKind() == "Secret" {
+				maskSecret(nil, o)
+			}
+			b, err := yaml.Marshal(o)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s---\n", b)
+			continue
+		}
+		text, err := diffObjects(id, live, result)
+		if err != nil {
+			return err
+		}
+		if len(text) != 0 {
+			changed++
+			fmt.Fprint(out, text)
+		}
+	}
+	if diff && changed == 0 && len(rejected) == 0 {
+		fmt.Fprintln(out, "no differences with the live resources")
+	}
+	if len(rejected) != 0 {
+		return fmt.Errorf("the server rejected %d resources:\n  %s", len(rejected), strings.Join(rejected, "\n  "))
+	}
+	return nil
+}
+
+// dryRunResource returns the live object, nil if it doesn't exist, and the object returned by the dry-run request.
+// The required object itself is returned if its namespace is only created by the dry-run.
+func dryRunResource(mapper meta.RESTMapper,
+	dynamicClient dynamic.Interface,
+	required *unstructured.Unstructured,
+	createdNamespaces map[string]bool) (live, result *unstructured.Unstructured, err error) {
+	gvk := required.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dr dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		dr = dynamicClient.Resource(mapping.Resource).Namespace(required.GetNamespace())
+	}
+	live, err = dr.Get(context.TODO(), required.GetName(), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		if createdNamespaces[required.GetNamespace()] {
+			return nil, required, nil
+		}
+		result, err = dr.Create(context.TODO(), required, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		return nil, result, err
+	case err != nil:
+		return nil, nil, err
+	}
+	updated := required.DeepCopy()
+	updated.SetResourceVersion(live.GetResourceVersion())
+	result, err = dr.Update(context.TODO(), updated, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	return live, result, err
+}
+
+// splitResources parses the rendered resources, a resource can contain several yaml documents
+func splitResources(resources []string) ([]*unstructured.Unstructured, error) {
+	objects := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, r := range resources {
+		decoder := yamlutil.NewYAMLOrJSONDecoder(strings.NewReader(r), 4096)
+		for {
+			o := make(map[string]interface{})
+			if err := decoder.Decode(&o); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, fmt.Errorf("unable to parse the resource %s: %v", r, err)
+			}
+			if len(o) == 0 {
+				continue
+			}
+			objects = append(objects, &unstructured.Unstructured{Object: o})
+		}
+	}
+	return objects, nil
+}
+
+func resourceID(o *unstructured.Unstructured) string {
+	if len(o.GetNamespace()) == 0 {
+		return fmt.Sprintf("%s %s", o.GetKind(), o.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", o.GetKind(), o.GetNamespace(), o.GetName())
+}
+
+// cleanObject removes the fields changing on every request
+func cleanObject(o *unstructured.Unstructured) map[string]interface{} {
+	if o == nil {
+		return nil
+	}
+	c := o.DeepCopy()
+	unstructured.RemoveNestedField(c.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(c.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(c.Object, "metadata", "generation")
+	return c.Object
+}
+
+// diffObjects returns the unified diff between the live object and the dry-run result, the secrets are masked
+func diffObjects(id string, live, result *unstructured.Unstructured) (string, error) {
+	before := cleanObject(live)
+	after := cleanObject(result)
+	if result.GetKind() == "Secret" {
+		maskSecret(before, after)
+	}
+	a := ""
+	if before != nil {
+		b, err := yaml.Marshal(before)
+		if err != nil {
+			return "", err
+		}
+		a = string(b)
+	}
+	b, err := yaml.Marshal(after)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: "live/" + id,
+		ToFile:   "dry-run/" + id,
+		Context:  3,
+	})
+}
+
+// maskSecret replaces the values of the secrets by *** and marks the changed ones, as kubectl diff does
+func maskSecret(before, after map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		var b, a map[string]interface{}
+		if before != nil {
+			b, _ = before[field].(map[string]interface{})
+		}
+		a, _ = after[field].(map[string]interface{})
+		for k, v := range a {
+			switch bv, ok := b[k]; {
+			case !ok:
+				a[k] = "***"
+			case bv == v:
+				a[k], b[k] = "***", "***"
+			default:
+				a[k], b[k] = "*** (after)", "*** (before)"
+			}
+		}
+		for k := range b {
+			if _, ok := a[k]; !ok {
+				b[k] = "***"
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestServerDryRun(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "namespaces", Kind: "Namespace", Namespaced: false},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+				{Name: "secrets", Kind: "Secret", Namespaced: true},
+			},
+		},
+	}
+	live := []runtime.Object{
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "config", "namespace": "hub", "resourceVersion": "1"},
+			"data":       map[string]interface{}{"region": "us-east-1", "size": "3"},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "creds", "namespace": "hub", "resourceVersion": "1"},
+			"data":       map[string]interface{}{"key": "b2xk", "id": "aWQ="},
+		}},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), live...)
+
+	resources := []string{
+		"apiVersion: v1\nkind: Namespace\nmetadata:\n  name: mycluster\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: install\n  namespace: mycluster\n",
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: hub\ndata:\n  region: us-east-2\n  size: \"3\"\n",
+		"apiVersion: v1\nkind: Secret\nmetadata:\n  name: creds\n  namespace: hub\ndata:\n  key: bmV3\n  id: aWQ=\n",
+		"apiVersion: hive.openshift.io/v1\nkind: ClusterDeployment\nmetadata:\n  name: mycluster\n  namespace: mycluster\n",
+	}
+	out := &bytes.Buffer{}
+	err := ServerDryRun(kubeClient, dynamicClient, resources, true, out)
+	if err == nil || !strings.Contains(err.Error(), "the server rejected 1 resources:\n  ClusterDeployment mycluster/mycluster:") {
+		t.Errorf("unexpected error %v", err)
+	}
+	for _, s := range []string{
+		"--- live/Namespace mycluster\n+++ dry-run/Namespace mycluster\n",
+		"# ConfigMap mycluster/install is not validated by the server, its namespace is created by this command\n",
+		"-  region: us-east-1\n+  region: us-east-2\n",
+		"   id: '***'\n-  key: '*** (before)'\n+  key: '*** (after)'\n",
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("output\n%s\ndoesn't contain\n%s", out.String(), s)
+		}
+	}
+	for _, s := range []string{"b2xk", "bmV3", "resourceVersion"} {
+		if strings.Contains(out.String(), s) {
+			t.Errorf("output\n%s\ncontains %s", out.String(), s)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	ExportFormatKustomize = "kustomize"
+	ExportFormatHelm      = "helm"
+
+	ExportSecretsFile           = "file"
+	ExportSecretsSealedSecret   = "sealedsecret"
+	ExportSecretsExternalSecret = "externalsecret"
+
+	// exportSecretsDir is the directory of the export holding the secrets,
+	// it is git-ignored when the secrets are replaced by stubs
+	exportSecretsDir = "secrets"
+)
+
+// ExportFlags are the options exporting the resources of a command in a directory for GitOps
+type ExportFlags struct {
+	// Dir is the directory to export the resources to, the resources are not applied if set
+	Dir string
+	// Format is kustomize or helm
+	Format string
+	// Secrets is how the secrets are exported: file, sealedsecret or externalsecret
+	Secrets string
+}
+
+// AddFlags adds --export-dir, --export-format and --export-secrets
+func (e *ExportFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&e.Dir, "export-dir", "",
+		"Export the generated resources in this directory, one file per resource, instead of applying them")
+	flags.StringVar(&e.Format, "export-format", ExportFormatKustomize,
+		fmt.Sprintf("The format of the export, %s (a kustomization.yaml) or %s (a chart)", ExportFormatKustomize, ExportFormatHelm))
+	flags.StringVar(&e.Secrets, "export-secrets", ExportSecretsFile,
+		fmt.Sprintf("How the secrets are exported, %s (in the %s directory), %s or %s (stubs, the secrets being kept in the git-ignored %s directory)",
+			ExportSecretsFile, exportSecretsDir, ExportSecretsSealedSecret, ExportSecretsExternalSecret, exportSecretsDir))
+}
+
+// IsSet returns true if the resources must be exported
+func (e *ExportFlags) IsSet() bool {
+	return len(e.Dir) != 0
+}
+
+// Validate checks the format and the secrets options
+func (e *ExportFlags) Validate() error {
+	if !e.IsSet() {
+		return nil
+	}
+	switch e.Format {
+	case ExportFormatKustomize, ExportFormatHelm:
+	default:
+		return fmt.Errorf("--export-format must be %s or %s and got %s", ExportFormatKustomize, ExportFormatHelm, e.Format)
+	}
+	switch e.Secrets {
+	case ExportSecretsFile, ExportSecretsSealedSecret, ExportSecretsExternalSecret:
+	default:
+		return fmt.Errorf("--export-secrets must be %s, %s or %s and got %s",
+			ExportSecretsFile, ExportSecretsSealedSecret, ExportSecretsExternalSecret, e.Secrets)
+	}
+	return nil
+}
+
+// Export writes the resources generated by a command, one file per resource in the order they are applied,
+// with a kustomization.yaml or as a helm chart
+func (e *ExportFlags) Export(output []string) error {
+	if !e.IsSet() {
+		return nil
+	}
+	objects, err := splitResources(output)
+	if err != nil {
+		return err
+	}
+	resourcesDir := e.Dir
+	if e.Format == ExportFormatHelm {
+		resourcesDir = filepath.Join(e.Dir, "templates")
+	}
+	if err := os.MkdirAll(resourcesDir, 0750); err != nil {
+		return err
+	}
+	resources := make([]string, 0, len(objects))
+	names := make(map[string]bool)
+	for _, o := range objects {
+		name := exportFileName(o, names)
+		if o.GetKind() == "Secret" {
+			file, err := e.exportSecret(o, name, resourcesDir)
+			if err != nil {
+				return err
+			}
+			resources = append(resources, file)
+			continue
+		}
+		if err := e.writeResource(filepath.Join(resourcesDir, name), o.Object, true); err != nil {
+			return err
+		}
+		resources = append(resources, name)
+	}
+	if e.Format == ExportFormatHelm {
+		return e.writeChart()
+	}
+	return writeYAML(filepath.Join(e.Dir, "kustomization.yaml"), map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	}, 0600)
+}
+
+// exportSecret writes the secret or its stub and returns the file to reference in the kustomization
+func (e *ExportFlags) exportSecret(secret *unstructured.Unstructured, name, resourcesDir string) (string, error) {
+	if e.Secrets == ExportSecretsFile {
+		secretsDir := filepath.Join(resourcesDir, exportSecretsDir)
+		if err := os.MkdirAll(secretsDir, 0700); err != nil {
+			return "", err
+		}
+		return filepath.Join(exportSecretsDir, name), e.writeResource(filepath.Join(secretsDir, name), secret.Object, true)
+	}
+	// The secret is kept out of git, to be sealed or pushed to the secret store
+	secretsDir := filepath.Join(e.Dir, exportSecretsDir)
+	if err := os.MkdirAll(secretsDir, 0700); err != nil {
+		return "", err
+	}
+	if err := e.writeResource(filepath.Join(secretsDir, name), secret.Object, false); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(e.Dir, ".gitignore"), []byte(exportSecretsDir+"/\n"), 0600); err != nil {
+		return "", err
+	}
+	stub, err := secretStub(secret, e.Secrets)
+	if err != nil {
+		return "", err
+	}
+	return name, e.writeResource(filepath.Join(resourcesDir, name), stub, true)
+}
+
+// writeResource writes the resource, escaping the helm template delimiters if it is a template of the chart
+func (e *ExportFlags) writeResource(path string, o map[string]interface{}, template bool) error {
+	b, err := yaml.Marshal(o)
+	if err != nil {
+		return err
+	}
+	if e.Format == ExportFormatHelm && template {
+		b = []byte(strings.ReplaceAll(string(b), "{{", `{{"{{"}}`))
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+func (e *ExportFlags) writeChart() error {
+	name := filepath.Base(filepath.Clean(e.Dir))
+	if err := writeYAML(filepath.Join(e.Dir, "Chart.yaml"), map[string]interface{}{
+		"apiVersion":  "v2",
+		"name":        name,
+		"description": "Resources exported by cm",
+		"type":        "application",
+		"version":     "0.1.0",
+	}, 0600); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(e.Dir, "values.yaml"), []byte{}, 0600)
+}
+
+// exportFileName returns <kind>-[<namespace>-]<name>.yaml, unique among the names already used
+func exportFileName(o *unstructured.Unstructured, names map[string]bool) string {
+	parts := []string{strings.ToLower(o.GetKind())}
+	if len(o.GetNamespace()) != 0 {
+		parts = append(parts, o.GetNamespace())
+	}
+	parts = append(parts, o.GetName())
+	base := strings.Join(parts, "-")
+	name := base + ".yaml"
+	for i := 2; names[name]; i++ {
+		name = fmt.Sprintf("%s-%d.yaml", base, i)
+	}
+	names[name] = true
+	return name
+}
+
+// secretStub returns a SealedSecret or an ExternalSecret with the name, type, labels and keys of the secret,
+// the encrypted data or the remote references must be completed
+func secretStub(secret *unstructured.Unstructured, kind string) (map[string]interface{}, error) {
+	keys := make([]string, 0)
+	for _, field := range []string{"data", "stringData"} {
+		m, _, err := unstructured.NestedMap(secret.Object, field)
+		if err != nil {
+			return nil, err
+		}
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	metadata := map[string]interface{}{
+		"name": secret.GetName(),
+	}
+	if len(secret.GetNamespace()) != 0 {
+		metadata["namespace"] = secret.GetNamespace()
+	}
+	templateMetadata := map[string]interface{}{}
+	if labels := secret.GetLabels(); len(labels) != 0 {
+		templateMetadata["labels"] = toInterfaceMap(labels)
+	}
+	if annotations := secret.GetAnnotations(); len(annotations) != 0 {
+		templateMetadata["annotations"] = toInterfaceMap(annotations)
+	}
+	secretType, _, _ := unstructured.NestedString(secret.Object, "type")
+	if kind == ExportSecretsSealedSecret {
+		encryptedData := make(map[string]interface{})
+		for _, k := range keys {
+			encryptedData[k] = "<kubeseal --raw>"
+		}
+		template := map[string]interface{}{"metadata": templateMetadata}
+		if len(secretType) != 0 {
+			template["type"] = secretType
+		}
+		return map[string]interface{}{
+			"apiVersion": "bitnami.com/v1alpha1",
+			"kind":       "SealedSecret",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"encryptedData": encryptedData,
+				"template":      template,
+			},
+		}, nil
+	}
+	data := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, map[string]interface{}{
+			"secretKey": k,
+			"remoteRef": map[string]interface{}{
+				"key":      fmt.Sprintf("%s/%s", secret.GetNamespace(), secret.GetName()),
+				"property": k,
+			},
+		})
+	}
+	template := map[string]interface{}{"metadata": templateMetadata}
+	if len(secretType) != 0 {
+		template["type"] = secretType
+	}
+	return map[string]interface{}{
+		"apiVersion": "external-secrets.io/v1beta1",
+		"kind":       "ExternalSecret",
+		"metadata":   metadata,
+		"spec": map[string]interface{}{
+			"secretStoreRef": map[string]interface{}{
+				"kind": "SecretStore",
+				"name": "<secret-store>",
+			},
+			"target": map[string]interface{}{
+				"name":     secret.GetName(),
+				"template": template,
+			},
+			"data": data,
+		},
+	}, nil
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	r := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		r[k] = v
+	}
+	return r
+}
+
+func writeYAML(path string, o interface{}, perm os.FileMode) error {
+	b, err := yaml.Marshal(o)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, perm)
+}
@@ -0,0 +1,141 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var exportResources = []string{
+	`apiVersion: v1
+kind: Namespace
+metadata:
+  name: mycluster
+`,
+	`apiVersion: v1
+kind: Secret
+metadata:
+  name: mycluster-creds
+  namespace: mycluster
+type: Opaque
+data:
+  token: dG9rZW4=
+`,
+	`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: mycluster-config
+  namespace: mycluster
+data:
+  template: "{{ .name }}"
+`,
+}
+
+func readExport(t *testing.T, dir, name string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("unable to read %s: %v", name, err)
+	}
+	return string(b)
+}
+
+func TestExportFlags_Export(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		secrets string
+		files   map[string][]string
+		missing []string
+	}{
+		{
+			name:    "kustomize",
+			format:  ExportFormatKustomize,
+			secrets: ExportSecretsFile,
+			files: map[string][]string{
+				"kustomization.yaml": {
+					"kind: Kustomization",
+					"- namespace-mycluster.yaml\n- secrets/secret-mycluster-mycluster-creds.yaml\n- configmap-mycluster-mycluster-config.yaml",
+				},
+				"secrets/secret-mycluster-mycluster-creds.yaml": {"token: dG9rZW4="},
+				"configmap-mycluster-mycluster-config.yaml":     {"template: '{{ .name }}'"},
+			},
+			missing: []string{".gitignore", "Chart.yaml"},
+		},
+		{
+			name:    "sealedsecret",
+			format:  ExportFormatKustomize,
+			secrets: ExportSecretsSealedSecret,
+			files: map[string][]string{
+				"kustomization.yaml":                            {"- secret-mycluster-mycluster-creds.yaml"},
+				"secret-mycluster-mycluster-creds.yaml":         {"kind: SealedSecret", "token: <kubeseal --raw>", "type: Opaque"},
+				"secrets/secret-mycluster-mycluster-creds.yaml": {"token: dG9rZW4="},
+				".gitignore": {"secrets/"},
+			},
+		},
+		{
+			name:    "externalsecret",
+			format:  ExportFormatKustomize,
+			secrets: ExportSecretsExternalSecret,
+			files: map[string][]string{
+				"secret-mycluster-mycluster-creds.yaml": {"kind: ExternalSecret", "key: mycluster/mycluster-creds", "property: token"},
+			},
+		},
+		{
+			name:    "helm",
+			format:  ExportFormatHelm,
+			secrets: ExportSecretsFile,
+			files: map[string][]string{
+				"Chart.yaml":  {"apiVersion: v2", "version: 0.1.0"},
+				"values.yaml": {},
+				"templates/configmap-mycluster-mycluster-config.yaml":     {`template: '{{"{{"}} .name }}'`},
+				"templates/secrets/secret-mycluster-mycluster-creds.yaml": {"token: dG9rZW4="},
+			},
+			missing: []string{"kustomization.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "export")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			e := &ExportFlags{Dir: filepath.Join(dir, "mycluster"), Format: tt.format, Secrets: tt.secrets}
+			if err := e.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.Export(exportResources); err != nil {
+				t.Fatal(err)
+			}
+			for name, wants := range tt.files {
+				content := readExport(t, e.Dir, name)
+				for _, want := range wants {
+					if !strings.Contains(content, want) {
+						t.Errorf("%s doesn't contain %q:\n%s", name, want, content)
+					}
+				}
+			}
+			for _, name := range tt.missing {
+				if _, err := os.Stat(filepath.Join(e.Dir, name)); !os.IsNotExist(err) {
+					t.Errorf("%s must not be exported", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExportFlags_Validate(t *testing.T) {
+	if err := (&ExportFlags{}).Validate(); err != nil {
+		t.Errorf("unset export must be valid, got %v", err)
+	}
+	if err := (&ExportFlags{Dir: "out", Format: "jsonnet", Secrets: ExportSecretsFile}).Validate(); err == nil {
+		t.Error("expected an error on an unknown format")
+	}
+	if err := (&ExportFlags{Dir: "out", Format: ExportFormatHelm, Secrets: "vault"}).Validate(); err == nil {
+		t.Error("expected an error on an unknown secrets mode")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/applier/pkg/asset"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// HookPhase is the moment a hook is run around a power state transition
+type HookPhase string
+
+const (
+	HookPhasePreHibernate  HookPhase = "pre-hibernate"
+	HookPhasePostHibernate HookPhase = "post-hibernate"
+	HookPhasePreRun        HookPhase = "pre-run"
+	HookPhasePostRun       HookPhase = "post-run"
+)
+
+// ClusterRunning returns true if the cluster is running when the hooks of the phase are run,
+// only then the kubeconfig of the cluster is available to the hooks.
+func (p HookPhase) ClusterRunning() bool {
+	return p == HookPhasePreHibernate || p == HookPhasePostRun
+}
+
+// Hooks are run before and after the hibernation and the resume of a cluster.
+// A hook is either a kubernetes manifest file (.yaml, .yml or .json) or a directory of manifests
+// applied on the cluster, or a command run with sh.
+type Hooks struct {
+	PreHibernate  []string `json:"preHibernate,omitempty"`
+	PostHibernate []string `json:"postHibernate,omitempty"`
+	PreRun        []string `json:"preRun,omitempty"`
+	PostRun       []string `json:"postRun,omitempty"`
+}
+
+// Get returns the hooks of a phase
+func (h *Hooks) Get(phase HookPhase) []string {
+	if h == nil {
+		return nil
+	}
+	switch phase {
+	case HookPhasePreHibernate:
+		return h.PreHibernate
+	case HookPhasePostHibernate:
+		return h.PostHibernate
+	case HookPhasePreRun:
+		return h.PreRun
+	case HookPhasePostRun:
+		return h.PostRun
+	}
+	return nil
+}
+
+// Override returns the hooks of h where the phases defined in o replace the ones of h
+func (h *Hooks) Override(o *Hooks) *Hooks {
+	r := &Hooks{}
+	if h != nil {
+		*r = *h
+	}
+	if o == nil {
+		return r
+	}
+	if len(o.PreHibernate) != 0 {
+		r.PreHibernate = o.PreHibernate
+	}
+	if len(o.PostHibernate) != 0 {
+		r.PostHibernate = o.PostHibernate
+	}
+	if len(o.PreRun) != 0 {
+		r.PreRun = o.PreRun
+	}
+	if len(o.PostRun) != 0 {
+		r.PostRun = o.PostRun
+	}
+	return r
+}
+
+// IsEmpty returns true if no hook is defined
+func (h *Hooks) IsEmpty() bool {
+	return h == nil ||
+		len(h.PreHibernate)+len(h.PostHibernate)+len(h.PreRun)+len(h.PostRun) == 0
+}
+
+// RunHooks runs the hooks of a phase for a cluster.
+// The kubeConfig is the configuration of the cluster, its current context is used by the manifests
+// and exported as KUBECONFIG to the commands, it is nil if the cluster is not reachable.
+// A failing hook stops the run unless force is set, in which case a warning is displayed.
+func RunHooks(hooks []string, phase HookPhase, clusterName string, kubeConfig *clientcmdapi.Config, force, dryRun bool) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+	if dryRun {
+		for _, hook := range hooks {
+			fmt.Printf("%s hook %s on cluster %s is not run (dry-run)\n", phase, hook, clusterName)
+		}
+		return nil
+	}
+	kubeConfigFile := ""
+	if kubeConfig != nil {
+		dir, err := ioutil.TempDir("", "cm-hook")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		kubeConfigFile = filepath.Join(dir, "kubeconfig")
+		if err := clientcmd.WriteToFile(*kubeConfig, kubeConfigFile); err != nil {
+			return err
+		}
+	}
+	for _, hook := range hooks {
+		fmt.Printf("Running %s hook %s on cluster %s\n", phase, hook, clusterName)
+		err := runHook(hook, phase, clusterName, kubeConfig, kubeConfigFile)
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("%s hook %s failed on cluster %s: %v", phase, hook, clusterName, err)
+		if !force {
+			return fmt.Errorf("%v, use --force to ignore the hook failures", err)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+func runHook(hook string, phase HookPhase, clusterName string, kubeConfig *clientcmdapi.Config, kubeConfigFile string) error {
+	if isManifestHook(hook) {
+		if kubeConfig == nil {
+			return fmt.Errorf("manifests can be applied only when the cluster is running, use them in pre-hibernate or post-run hooks")
+		}
+		return applyManifestHook(hook, phase, clusterName, kubeConfig)
+	}
+	// #nosec G204
+	cmd := exec.Command("sh", "-c", hook)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"CM_HOOK_PHASE="+string(phase),
+		"CM_HOOK_CLUSTER="+clusterName,
+	)
+	if len(kubeConfigFile) != 0 {
+		cmd.Env = append(cmd.Env, "KUBECONFIG="+kubeConfigFile)
+	}
+	return cmd.Run()
+}
+
+// isManifestHook returns true if the hook is a manifest file or a directory
+func isManifestHook(hook string) bool {
+	fi, err := os.Stat(hook)
+	if err != nil {
+		return false
+	}
+	return fi.IsDir() || isManifestFile(hook)
+}
+
+func isManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// applyManifestHook applies the manifests, they are templated with the ClusterName and the Phase values
+func applyManifestHook(hook string, phase HookPhase, clusterName string, kubeConfig *clientcmdapi.Config) error {
+	restConfig, err := clientcmd.NewDefaultClientConfig(*kubeConfig, nil).ClientConfig()
+	if err != nil {
+		return err
+	}
+	reader, err := asset.NewDirectoriesReader("", []string{hook})
+	if err != nil {
+		return err
+	}
+	names, err := reader.AssetNames([]string{hook}, nil, "")
+	if err != nil {
+		return err
+	}
+	files := make([]string, 0)
+	for _, name := range names {
+		if isManifestFile(name) {
+			files = append(files, name)
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no manifest found in %s", hook)
+	}
+	values := map[string]interface{}{
+		"ClusterName": clusterName,
+		"Phase":       string(phase),
+	}
+	applier := apply.NewApplierBuilder().WithRestConfig(restConfig).Build()
+	_, err = applier.Apply(reader, values, false, "", files...)
+	return err
+}
@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestHooksOverride(t *testing.T) {
+	cphHooks := &Hooks{
+		PreHibernate: []string{"drain.sh"},
+		PostRun:      []string{"approve-csrs.sh"},
+	}
+	got := cphHooks.Override(&Hooks{PreHibernate: []string{"snapshot.sh"}})
+	want := &Hooks{
+		PreHibernate: []string{"snapshot.sh"},
+		PostRun:      []string{"approve-csrs.sh"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Override() = %+v, want %+v", got, want)
+	}
+	if len(cphHooks.PreHibernate) != 1 || cphHooks.PreHibernate[0] != "drain.sh" {
+		t.Errorf("Override() must not modify the receiver, got %+v", cphHooks)
+	}
+	var nilHooks *Hooks
+	if !nilHooks.Override(nil).IsEmpty() || nilHooks.Get(HookPhasePostRun) != nil {
+		t.Error("nil hooks must be empty")
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "output")
+	kubeConfig := clientcmdapi.NewConfig()
+	kubeConfig.Clusters["mycluster"] = &clientcmdapi.Cluster{Server: "https://api.mycluster:6443"}
+	kubeConfig.Contexts["mycluster"] = &clientcmdapi.Context{Cluster: "mycluster"}
+	kubeConfig.CurrentContext = "mycluster"
+
+	script := fmt.Sprintf("echo \"$CM_HOOK_PHASE $CM_HOOK_CLUSTER $(grep current-context $KUBECONFIG)\" > %s", outputFile)
+	if err := RunHooks([]string{script}, HookPhasePreHibernate, "mycluster", kubeConfig, false, false); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(b)) != "pre-hibernate mycluster current-context: mycluster" {
+		t.Errorf("unexpected hook output %q", string(b))
+	}
+
+	err = RunHooks([]string{"exit 1", script}, HookPhasePostRun, "mycluster", kubeConfig, false, false)
+	if err == nil || !strings.Contains(err.Error(), "use --force") {
+		t.Errorf("expected a hook failure, got %v", err)
+	}
+
+	if err := RunHooks([]string{"exit 1", script}, HookPhasePostRun, "mycluster", kubeConfig, true, false); err != nil {
+		t.Errorf("the failure must be ignored with force, got %v", err)
+	}
+	b, err = ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "post-run mycluster") {
+		t.Errorf("the hook following the failure must run with force, got %q", string(b))
+	}
+
+	manifest := filepath.Join(dir, "job.yaml")
+	if err := ioutil.WriteFile(manifest, []byte("kind: Job\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err = RunHooks([]string{manifest}, HookPhasePreRun, "mycluster", nil, false, false)
+	if err == nil || !strings.Contains(err.Error(), "only when the cluster is running") {
+		t.Errorf("expected an error for a manifest on a hibernated cluster, got %v", err)
+	}
+}
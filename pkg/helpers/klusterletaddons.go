@@ -5,6 +5,7 @@ package helpers
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -19,20 +20,31 @@ import (
 func WaitKlusterlet(clusterClient clusterclientset.Interface,
 	clusterName string,
 	timeout int) error {
-	return wait.PollImmediate(10*time.Second, time.Duration(timeout)*time.Second, func() (bool, error) {
-		return checkManagedClusterAvailable(clusterClient, clusterName)
+	progress := NewProgress(os.Stdout)
+	defer progress.Stop()
+	start := time.Now()
+	err := wait.PollImmediate(10*time.Second, time.Duration(timeout)*time.Second, func() (bool, error) {
+		return checkManagedClusterAvailable(clusterClient, clusterName, progress)
 	})
+	NotifyWaitDone(fmt.Sprintf("agent on %s", clusterName), start, err)
+	return err
 }
 
 func WaitKlusterletAddons(workClient workclientset.Interface,
 	clusterName string,
 	timeout int) error {
-	return wait.PollImmediate(10*time.Second, time.Duration(timeout)*time.Second, func() (bool, error) {
-		return checkManagedClusterAddons(workClient, clusterName)
+	progress := NewProgress(os.Stdout)
+	defer progress.Stop()
+	start := time.Now()
+	err := wait.PollImmediate(10*time.Second, time.Duration(timeout)*time.Second, func() (bool, error) {
+		return checkManagedClusterAddons(workClient, clusterName, progress)
 	})
+	NotifyWaitDone(fmt.Sprintf("addons on %s", clusterName), start, err)
+	return err
 }
 
-func checkManagedClusterAvailable(clusterClient clusterclientset.Interface, clusterName string) (bool, error) {
+func checkManagedClusterAvailable(clusterClient clusterclientset.Interface, clusterName string, progress *Progress) (bool, error) {
+	name := fmt.Sprintf("agent on %s", clusterName)
 	mc, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
 	if err != nil {
 		return false, err
@@ -40,15 +52,15 @@ func checkManagedClusterAvailable(clusterClient clusterclientset.Interface, clus
 	for _, condition := range mc.Status.Conditions {
 		if condition.Type == clusterv1.ManagedClusterConditionAvailable &&
 			condition.Status == metav1.ConditionTrue {
-			fmt.Printf("agent on %s ready\n", clusterName)
+			progress.Done(name, "Ready", condition.Reason)
 			return true, nil
 		}
 	}
-	fmt.Printf("agent on %s not ready\n", clusterName)
+	progress.Update(name, "NotReady", LastConditionReason(mc.Status.Conditions))
 	return false, nil
 }
 
-func checkManagedClusterAddons(workClient workclientset.Interface, clusterName string) (bool, error) {
+func checkManagedClusterAddons(workClient workclientset.Interface, clusterName string, progress *Progress) (bool, error) {
 	mwl, err := workClient.WorkV1().ManifestWorks(clusterName).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return false, err
@@ -59,18 +71,33 @@ func checkManagedClusterAddons(workClient workclientset.Interface, clusterName s
 			continue
 		}
 		addon := strings.TrimPrefix(mw.Name, clusterName+"-klusterlet-addon-")
+		name := fmt.Sprintf("addon %s on %s", addon, clusterName)
 		addonReady := false
 		for _, condition := range mw.Status.Conditions {
 			if condition.Type == workv1.WorkAvailable &&
 				condition.Status == metav1.ConditionTrue {
 				addonReady = true
-				fmt.Printf("addon %s on %s ready\n", addon, clusterName)
+				progress.Done(name, "Ready", condition.Reason)
 			}
-			fmt.Printf("addon %s on %s not ready\n", addon, clusterName)
 		}
 		if !addonReady {
 			allReady = false
+			progress.Update(name, "NotReady", LastConditionReason(mw.Status.Conditions))
 		}
 	}
 	return allReady, nil
 }
+
+// LastConditionReason returns "<type> <reason>" of the last transitioned condition
+func LastConditionReason(conditions []metav1.Condition) string {
+	var last *metav1.Condition
+	for i := range conditions {
+		if last == nil || last.LastTransitionTime.Before(&conditions[i].LastTransitionTime) {
+			last = &conditions[i]
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s", last.Type, last.Reason)
+}
@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	NotificationOutcomeSucceeded = "succeeded"
+	NotificationOutcomeFailed    = "failed"
+	NotificationOutcomeTimeout   = "timeout"
+
+	notificationTimeout = 10 * time.Second
+)
+
+// Notification is the payload sent when a wait loop completes or fails
+type Notification struct {
+	// The cm command which was running
+	Command string `json:"command"`
+	// The resources the command was waiting for
+	Target string `json:"target"`
+	// succeeded, failed or timeout
+	Outcome string `json:"outcome"`
+	// The wait duration in seconds
+	Duration float64 `json:"duration"`
+	// The error message if the outcome is not succeeded
+	Error string `json:"error,omitempty"`
+}
+
+// Notifier sends the notifications to HTTP webhooks or local commands
+type Notifier struct {
+	// URLs starting with http:// or https:// are webhooks, other targets are local commands
+	targets []string
+	command string
+	client  *http.Client
+}
+
+var notifier = &Notifier{}
+
+// SetNotifier configures the notifications of the wait loops of the running command
+func SetNotifier(targets []string, command string) {
+	notifier = &Notifier{
+		targets: targets,
+		command: command,
+		client:  &http.Client{Timeout: notificationTimeout},
+	}
+}
+
+// NotifyWaitDone notifies that a wait loop started at start completed with err.
+// The notification errors are reported as warnings.
+func NotifyWaitDone(target string, start time.Time, err error) {
+	if len(notifier.targets) == 0 {
+		return
+	}
+	n := &Notification{
+		Command:  notifier.command,
+		Target:   target,
+		Outcome:  NotificationOutcomeSucceeded,
+		Duration: time.Since(start).Round(time.Second).Seconds(),
+	}
+	switch {
+	case err == wait.ErrWaitTimeout:
+		n.Outcome = NotificationOutcomeTimeout
+		n.Error = err.Error()
+	case err != nil:
+		n.Outcome = NotificationOutcomeFailed
+		n.Error = err.Error()
+	}
+	for _, errN := range notifier.Notify(n) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", errN)
+	}
+}
+
+// Notify sends the notification to all targets and returns the errors
+func (nr *Notifier) Notify(n *Notification) []error {
+	errs := make([]error, 0)
+	b, err := json.Marshal(n)
+	if err != nil {
+		return append(errs, err)
+	}
+	for _, target := range nr.targets {
+		if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+			err = nr.post(target, b)
+		} else {
+			err = nr.exec(target, n, b)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notification to %s failed: %v", target, err))
+		}
+	}
+	return errs
+}
+
+func (nr *Notifier) post(url string, payload []byte) error {
+	resp, err := nr.client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// exec runs the command with sh, the payload is sent on stdin and the fields as CM_NOTIFY_* environment variables
+func (nr *Notifier) exec(command string, n *Notification, payload []byte) error {
+	// #nosec G204
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"CM_NOTIFY_COMMAND="+n.Command,
+		"CM_NOTIFY_TARGET="+n.Target,
+		"CM_NOTIFY_OUTCOME="+n.Outcome,
+		fmt.Sprintf("CM_NOTIFY_DURATION=%.0f", n.Duration),
+		"CM_NOTIFY_ERROR="+n.Error,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestNotifyWaitDone(t *testing.T) {
+	received := make(chan Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := Notification{}
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Error(err)
+		}
+		received <- n
+	}))
+	defer server.Close()
+
+	outputFile := filepath.Join(t.TempDir(), "notification")
+	SetNotifier([]string{
+		server.URL,
+		fmt.Sprintf("echo \"$CM_NOTIFY_TARGET $CM_NOTIFY_OUTCOME\" > %s", outputFile),
+	}, "cm create cc")
+	defer SetNotifier(nil, "")
+
+	NotifyWaitDone("clusterclaim mycc", time.Now(), wait.ErrWaitTimeout)
+
+	n := <-received
+	if n.Command != "cm create cc" || n.Target != "clusterclaim mycc" || n.Outcome != NotificationOutcomeTimeout || len(n.Error) == 0 {
+		t.Errorf("unexpected notification %+v", n)
+	}
+	b, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(b)) != "clusterclaim mycc timeout" {
+		t.Errorf("unexpected command output %q", string(b))
+	}
+}
+
+func TestNotifyErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	SetNotifier([]string{server.URL, "exit 1"}, "cm install mce")
+	defer SetNotifier(nil, "")
+
+	errs := notifier.Notify(&Notification{Outcome: NotificationOutcomeSucceeded})
+	if len(errs) != 2 {
+		t.Errorf("expected 2 errors, got %v", errs)
+	}
+}
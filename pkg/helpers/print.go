@@ -53,7 +53,7 @@ func Print(obj runtime.Object, printFlags *get.PrintFlags) error {
 	return printer.PrintObj(obj, os.Stdout)
 }
 
-func searchCRD(reader *asset.ScenarioResourcesReader, kind string) (*apiextensionsv1.CustomResourceDefinition, error) {
+func searchCRD(reader asset.ScenarioReader, kind string) (*apiextensionsv1.CustomResourceDefinition, error) {
 	crdFileNames, err := reader.AssetNames(nil, nil, "")
 	if err != nil {
 		return nil, err
@@ -0,0 +1,217 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const progressRefreshInterval = 250 * time.Millisecond
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// Progress renders the progress of long running waits.
+// On a terminal the items are updated in place with a spinner,
+// otherwise a line is written each time the phase or the reason of an item changes.
+type Progress struct {
+	out   io.Writer
+	tty   bool
+	mutex sync.Mutex
+	items []*progressItem
+	// number of lines written by the last in place rendering
+	lines   int
+	frame   int
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+type progressItem struct {
+	name   string
+	phase  string
+	reason string
+	start  time.Time
+	end    time.Time
+	done   bool
+}
+
+// NewProgress returns a progress renderer writing on out
+func NewProgress(out io.Writer) *Progress {
+	return &Progress{
+		out: out,
+		tty: IsTerminal(out),
+	}
+}
+
+// IsTerminal returns true if out is a terminal and the CI environment variable is not set
+func IsTerminal(out io.Writer) bool {
+	if len(os.Getenv("CI")) != 0 {
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Update sets the current phase and reason of an item, the item is added if it doesn't exist
+func (p *Progress) Update(name, phase, reason string) {
+	p.update(name, phase, reason, false)
+}
+
+// Done sets the final phase and reason of an item, its elapsed time stops
+func (p *Progress) Done(name, phase, reason string) {
+	p.update(name, phase, reason, true)
+}
+
+// Stop stops the refresh of the rendering and renders the final state
+func (p *Progress) Stop() {
+	p.mutex.Lock()
+	stop := p.stop
+	p.stop = nil
+	p.mutex.Unlock()
+	if stop != nil {
+		close(stop)
+		<-p.stopped
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.tty {
+		p.render()
+	}
+}
+
+func (p *Progress) update(name, phase, reason string, done bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	reason = firstLine(reason)
+	var item *progressItem
+	for _, i := range p.items {
+		if i.name == name {
+			item = i
+			break
+		}
+	}
+	if item == nil {
+		item = &progressItem{name: name, start: time.Now()}
+		p.items = append(p.items, item)
+	}
+	changed := item.phase != phase || item.reason != reason || item.done != done
+	item.phase = phase
+	item.reason = reason
+	if done && !item.done {
+		item.end = time.Now()
+	}
+	item.done = done
+	if p.tty {
+		p.render()
+		p.startRefresh()
+		return
+	}
+	if changed {
+		fmt.Fprintln(p.out, item.format(""))
+	}
+}
+
+// startRefresh starts the goroutine refreshing the spinner and the elapsed times
+func (p *Progress) startRefresh() {
+	if p.stop != nil {
+		return
+	}
+	p.stop = make(chan struct{})
+	p.stopped = make(chan struct{})
+	go func(stop, stopped chan struct{}) {
+		defer close(stopped)
+		ticker := time.NewTicker(progressRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				p.mutex.Lock()
+				p.frame++
+				p.render()
+				p.mutex.Unlock()
+			}
+		}
+	}(p.stop, p.stopped)
+}
+
+// render rewrites all items in place, the caller must hold the mutex
+func (p *Progress) render() {
+	var b strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", p.lines)
+	}
+	for _, item := range p.items {
+		spinner := spinnerFrames[p.frame%len(spinnerFrames)]
+		if item.done {
+			spinner = "*"
+		}
+		fmt.Fprintf(&b, "\x1b[2K%s\n", item.format(spinner))
+	}
+	p.lines = len(p.items)
+	fmt.Fprint(p.out, b.String())
+}
+
+func (i *progressItem) format(spinner string) string {
+	end := time.Now()
+	if i.done {
+		end = i.end
+	}
+	var b strings.Builder
+	if len(spinner) != 0 {
+		fmt.Fprintf(&b, "%s ", spinner)
+	}
+	fmt.Fprintf(&b, "%s: %s [%s]", i.name, i.phase, end.Sub(i.start).Round(time.Second))
+	if len(i.reason) != 0 {
+		fmt.Fprintf(&b, " %s", i.reason)
+	}
+	return b.String()
+}
+
+func firstLine(s string) string {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// UnstructuredPhaseAndReason returns the status.phase of an object and
+// "<type> <reason>" of its last transitioned status.conditions
+func UnstructuredPhaseAndReason(obj map[string]interface{}) (phase, reason string) {
+	phase, _, _ = unstructured.NestedString(obj, "status", "phase")
+	conditions, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	var lastTime time.Time
+	for _, cu := range conditions {
+		c, ok := cu.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ts, _, _ := unstructured.NestedString(c, "lastTransitionTime")
+		if len(ts) == 0 {
+			ts, _, _ = unstructured.NestedString(c, "lastUpdateTime")
+		}
+		t, _ := time.Parse(time.RFC3339, ts)
+		if len(reason) != 0 && t.Before(lastTime) {
+			continue
+		}
+		lastTime = t
+		ctype, _, _ := unstructured.NestedString(c, "type")
+		creason, _, _ := unstructured.NestedString(c, "reason")
+		reason = fmt.Sprintf("%s %s", ctype, creason)
+	}
+	return phase, reason
+}
@@ -0,0 +1,68 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestProgressLineMode(t *testing.T) {
+	var out bytes.Buffer
+	p := NewProgress(&out)
+	if p.tty {
+		t.Fatal("a buffer must not be considered as a terminal")
+	}
+	p.Update("cc1", "Pending", "NoClusters")
+	p.Update("cc1", "Pending", "NoClusters")
+	p.Update("cc2", "Installing", "ProvisionFailed\nhint: retry")
+	p.Update("cc1", "Installing", "")
+	p.Done("cc1", "Running", "id mycd")
+	p.Done("cc1", "Running", "id mycd")
+	p.Stop()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		"cc1: Pending [0s] NoClusters",
+		"cc2: Installing [0s] ProvisionFailed",
+		"cc1: Installing [0s]",
+		"cc1: Running [0s] id mycd",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: got %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestUnstructuredPhaseAndReason(t *testing.T) {
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase": "Installing",
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":               "Progressing",
+					"reason":             "ComponentsInstalling",
+					"lastTransitionTime": "2022-05-01T10:00:00Z",
+				},
+				map[string]interface{}{
+					"type":               "Available",
+					"reason":             "ComponentsNotReady",
+					"lastTransitionTime": "2022-05-01T09:00:00Z",
+				},
+			},
+		},
+	}
+	phase, reason := UnstructuredPhaseAndReason(obj)
+	if phase != "Installing" || reason != "Progressing ComponentsInstalling" {
+		t.Errorf("got %q %q", phase, reason)
+	}
+	phase, reason = UnstructuredPhaseAndReason(map[string]interface{}{})
+	if phase != "" || reason != "" {
+		t.Errorf("got %q %q for an object without status", phase, reason)
+	}
+}
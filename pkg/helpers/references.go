@@ -0,0 +1,133 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// referenceRegexp matches the ${env:<variable>}, ${file:<path>} and ${exec:<command>} references,
+// a reference preceded by $ (ie: $${env:HOME}) is escaped and left as ${env:HOME}.
+var referenceRegexp = regexp.MustCompile(`\$?\$\{(env|file|exec):([^}]*)\}`)
+
+// ResolveReferences replaces the references in the string values by:
+// - ${env:<variable>}: the value of the environment variable, which must be set
+// - ${file:<path>}: the content of the file, a leading ~/ is the home directory
+// - ${exec:<command>}: the output of the command run with sh -c, without the trailing newlines
+// All unresolved references are reported with their path.
+func ResolveReferences(values map[string]interface{}) error {
+	errs := resolveReferences("", values)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unable to resolve the references:\n  %s", strings.Join(errs, "\n  "))
+}
+
+func resolveReferences(path string, value interface{}) []string {
+	errs := make([]string, 0)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if s, ok := v[k].(string); ok {
+				resolved, sErrs := resolveString(joinPath(path, k), s)
+				v[k] = resolved
+				errs = append(errs, sErrs...)
+				continue
+			}
+			errs = append(errs, resolveReferences(joinPath(path, k), v[k])...)
+		}
+	case []interface{}:
+		for i := range v {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			if s, ok := v[i].(string); ok {
+				resolved, sErrs := resolveString(p, s)
+				v[i] = resolved
+				errs = append(errs, sErrs...)
+				continue
+			}
+			errs = append(errs, resolveReferences(p, v[i])...)
+		}
+	}
+	return errs
+}
+
+func resolveString(path, s string) (string, []string) {
+	errs := make([]string, 0)
+	resolved := referenceRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := referenceRegexp.FindStringSubmatch(ref)
+		value, err := resolveReference(m[1], m[2])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s: %v", displayPath(path), ref, err))
+			return ref
+		}
+		return value
+	})
+	return resolved, errs
+}
+
+func resolveReference(source, arg string) (string, error) {
+	arg = strings.TrimSpace(arg)
+	if len(arg) == 0 {
+		return "", fmt.Errorf("the %s reference is empty", source)
+	}
+	switch source {
+	case "env":
+		v, ok := os.LookupEnv(arg)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", arg)
+		}
+		return v, nil
+	case "file":
+		arg, err := expandHome(arg)
+		if err != nil {
+			return "", err
+		}
+		b, err := ioutil.ReadFile(filepath.Clean(arg))
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	case "exec":
+		// #nosec G204
+		cmd := exec.Command("sh", "-c", arg)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); len(msg) != 0 {
+				return "", fmt.Errorf("%v: %s", err, msg)
+			}
+			return "", err
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+	}
+	return "", fmt.Errorf("unsupported reference source %s", source)
+}
+
+// expandHome replaces a leading ~/ of the path by the home directory
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[2:]), nil
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveReferences(t *testing.T) {
+	dir := t.TempDir()
+	pubKey := filepath.Join(dir, "id_rsa.pub")
+	if err := ioutil.WriteFile(pubKey, []byte("ssh-rsa AAAA\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CM_TEST_AWS_SECRET", "secret")
+	values := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name":          "${env:CM_TEST_AWS_SECRET}-cluster",
+			"sshPublicKey":  "${file:" + pubKey + "}",
+			"installConfig": "$${env:HOME}",
+			"aws": map[string]interface{}{
+				"awsSecretAccessKeyID": "${exec:echo key}",
+				"workerReplicas":       float64(3),
+				"zones":                []interface{}{"${exec:printf us-east-1a}"},
+			},
+		},
+	}
+	if err := ResolveReferences(values); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name":          "secret-cluster",
+			"sshPublicKey":  "ssh-rsa AAAA\n",
+			"installConfig": "${env:HOME}",
+			"aws": map[string]interface{}{
+				"awsSecretAccessKeyID": "key",
+				"workerReplicas":       float64(3),
+				"zones":                []interface{}{"us-east-1a"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("ResolveReferences() = %v, want %v", values, want)
+	}
+}
+
+func TestResolveReferencesErrors(t *testing.T) {
+	values := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"pullSecret": "${file:/does/not/exist}",
+			"aws": map[string]interface{}{
+				"awsAccessKeyID":       "${env:CM_TEST_NOT_SET}",
+				"awsSecretAccessKeyID": "${exec:exit 1}",
+			},
+		},
+	}
+	err := ResolveReferences(values)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, s := range []string{
+		"managedCluster.aws.awsAccessKeyID: ${env:CM_TEST_NOT_SET}: environment variable CM_TEST_NOT_SET is not set",
+		"managedCluster.aws.awsSecretAccessKeyID: ${exec:exit 1}",
+		"managedCluster.pullSecret: ${file:/does/not/exist}",
+	} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q doesn't contain %q", err.Error(), s)
+		}
+	}
+}
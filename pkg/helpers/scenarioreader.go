@@ -0,0 +1,146 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"embed"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stolostron/applier/pkg/asset"
+)
+
+// ScenarioDirEnv is the environment variable defining the scenario directory when --scenario-dir is not set
+const ScenarioDirEnv = "CM_SCENARIO_DIR"
+
+var scenarioDir string
+
+// SetScenarioDir sets the directory holding the user templates overlaying the embedded scenarios,
+// the CM_SCENARIO_DIR environment variable is used if dir is empty
+func SetScenarioDir(dir string) {
+	if len(dir) == 0 {
+		dir = os.Getenv(ScenarioDirEnv)
+	}
+	scenarioDir = dir
+}
+
+// GetScenarioDir returns the directory holding the user templates, empty if not set
+func GetScenarioDir() string {
+	return scenarioDir
+}
+
+// ScenarioReader reads the assets of a scenario, an asset found in <scenario_dir>/<name>/<asset>
+// replaces the embedded one, the other files of the directory are added to the scenario.
+type ScenarioReader struct {
+	// Name of the scenario, the directory of its templates in the scenario directory
+	Name string
+	// Builtin is the reader of the embedded assets
+	Builtin *asset.ScenarioResourcesReader
+}
+
+var _ asset.ScenarioReader = &ScenarioReader{}
+
+// NewScenarioReader returns the reader of the embedded scenario overlaid by the scenario directory
+func NewScenarioReader(name string, files *embed.FS) *ScenarioReader {
+	return &ScenarioReader{
+		Name:    name,
+		Builtin: asset.NewScenarioResourcesReader(files),
+	}
+}
+
+// Dir returns the directory of the user templates of the scenario, empty if no scenario directory is set
+func (r *ScenarioReader) Dir() string {
+	if len(scenarioDir) == 0 {
+		return ""
+	}
+	return filepath.Join(scenarioDir, r.Name)
+}
+
+// Overridden returns true if the asset is read from the scenario directory
+func (r *ScenarioReader) Overridden(name string) bool {
+	if len(r.Dir()) == 0 {
+		return false
+	}
+	fi, err := os.Stat(filepath.Join(r.Dir(), filepath.FromSlash(name)))
+	return err == nil && !fi.IsDir()
+}
+
+// Asset returns the user template if it exists and the embedded asset otherwise
+func (r *ScenarioReader) Asset(name string) ([]byte, error) {
+	if r.Overridden(name) {
+		return ioutil.ReadFile(filepath.Join(r.Dir(), filepath.FromSlash(name)))
+	}
+	return r.Builtin.Asset(name)
+}
+
+// AssetNames returns the names of the embedded assets and of the user templates
+func (r *ScenarioReader) AssetNames(prefixes, excluded []string, headerFile string) ([]string, error) {
+	names, err := r.Builtin.AssetNames(prefixes, excluded, headerFile)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := r.overlayNames()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range overlay {
+		if isAssetSelected(name, prefixes, excluded) {
+			names = asset.AppendItNotExists(names, name)
+		}
+	}
+	return names, nil
+}
+
+// overlayNames returns the sorted names of the user templates of the scenario
+func (r *ScenarioReader) overlayNames() ([]string, error) {
+	names := make([]string, 0)
+	dir := r.Dir()
+	if len(dir) == 0 {
+		return names, nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return names, nil
+	}
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the templates of the scenario %s: %v", r.Name, err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// isAssetSelected returns true if the name starts with one of the prefixes, all names if prefixes is nil,
+// and is not excluded, as the applier readers do
+func isAssetSelected(name string, prefixes, excluded []string) bool {
+	for _, e := range excluded {
+		if name == e {
+			return false
+		}
+	}
+	if prefixes == nil {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"embed"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stolostron/applier/pkg/apply"
+)
+
+//go:embed testdata/scenario testdata/scenario/create/common/_helpers.tpl
+var testScenarioFiles embed.FS
+
+const (
+	testScenarioHeader    = "testdata/scenario/create/common/_helpers.tpl"
+	testScenarioConfigMap = "testdata/scenario/create/common/configmap.yaml"
+)
+
+func writeOverlay(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, "test", filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScenarioReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scenario")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer SetScenarioDir("")
+
+	r := NewScenarioReader("test", &testScenarioFiles)
+	applier := apply.NewApplierBuilder().Build()
+	values := map[string]interface{}{"name": "mycm"}
+
+	SetScenarioDir("")
+	if len(r.Dir()) != 0 || r.Overridden(testScenarioConfigMap) {
+		t.Errorf("no file must be overridden without a scenario directory")
+	}
+	b, err := applier.MustTemplateAsset(r, values, testScenarioHeader, testScenarioConfigMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "app: mycm") {
+		t.Errorf("expected the embedded helpers to be used, got:\n%s", b)
+	}
+
+	SetScenarioDir(dir)
+	writeOverlay(t, dir, testScenarioHeader, "{{- define \"labels\" }}\n    app: {{ .name }}\n    team: edge\n{{- end }}\n")
+	writeOverlay(t, dir, testScenarioConfigMap, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .name }}\n  annotations:\n    owner: {{ .name | upper }}\n  labels:\n    {{- template \"labels\" . }}\n")
+	writeOverlay(t, dir, "testdata/scenario/create/common/secret.yaml", "apiVersion: v1\nkind: Secret\n")
+	if !r.Overridden(testScenarioConfigMap) {
+		t.Errorf("%s must be overridden", testScenarioConfigMap)
+	}
+	b, err = applier.MustTemplateAsset(r, values, testScenarioHeader, testScenarioConfigMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"owner: MYCM", "app: mycm", "team: edge"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %q rendered with the overlay templates, got:\n%s", want, b)
+		}
+	}
+	builtin, err := r.Builtin.Asset(testScenarioConfigMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(builtin), "owner") {
+		t.Errorf("the embedded asset must not be changed")
+	}
+
+	names, err := r.AssetNames([]string{"testdata/scenario/create"}, []string{testScenarioHeader}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{testScenarioConfigMap, "testdata/scenario/create/common/secret.yaml"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	names, err = r.AssetNames([]string{"testdata/other"}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Errorf("expected no asset out of the prefixes, got %v", names)
+	}
+}
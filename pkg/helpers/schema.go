@@ -0,0 +1,294 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/stolostron/applier/pkg/asset"
+)
+
+// ValuesSchemaFileName is the name of the JSON schema stored next to the values-template.yaml of a scenario
+const ValuesSchemaFileName = "values-schema.json"
+
+// Schema is the subset of JSON schema used to validate the values files:
+// type, properties, required, additionalProperties, items, enum, minimum, maximum and default.
+// A null value, as left by an empty field of a values-template.yaml, is considered as not set.
+// The x-prompt extension drives the --interactive mode of the commands,
+// the x-flag extension names the options of the command overriding the field.
+type Schema struct {
+	Description          string                `json:"description,omitempty"`
+	Type                 string                `json:"type,omitempty"`
+	Properties           map[string]*Schema    `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty"`
+	Items                *Schema               `json:"items,omitempty"`
+	Enum                 []interface{}         `json:"enum,omitempty"`
+	Minimum              *float64              `json:"minimum,omitempty"`
+	Maximum              *float64              `json:"maximum,omitempty"`
+	Default              interface{}           `json:"default,omitempty"`
+	Prompt               *Prompt               `json:"x-prompt,omitempty"`
+	Flag                 string                `json:"x-flag,omitempty"`
+}
+
+// AdditionalProperties is either a boolean or the schema of the properties not listed in properties
+type AdditionalProperties struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *AdditionalProperties) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	a.Schema = &Schema{}
+	return json.Unmarshal(b, a.Schema)
+}
+
+// LoadSchema reads the JSON schema schemaPath of the reader
+func LoadSchema(reader asset.ScenarioReader, schemaPath string) (*Schema, error) {
+	b, err := reader.Asset(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("unable to parse the schema %s: %v", schemaPath, err)
+	}
+	return schema, nil
+}
+
+// ValidateValues validates the values against the JSON schema schemaPath of the reader
+func ValidateValues(reader asset.ScenarioReader, schemaPath string, values map[string]interface{}) error {
+	schema, err := LoadSchema(reader, schemaPath)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(values)
+}
+
+// Validate returns an error listing all the violations of the schema by the values
+func (s *Schema) Validate(values map[string]interface{}) error {
+	errs := s.validate("", values)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid values:\n  %s", strings.Join(errs, "\n  "))
+}
+
+// Lookup returns the schema of the field at the dotted path, the items of the arrays are traversed
+func (s *Schema) Lookup(dotedPath string) (*Schema, error) {
+	current := s
+	if len(dotedPath) == 0 {
+		return current, nil
+	}
+	path := ""
+	for _, k := range strings.Split(dotedPath, ".") {
+		for current.Items != nil {
+			current = current.Items
+		}
+		p, ok := current.Properties[k]
+		if !ok && current.AdditionalProperties != nil && current.AdditionalProperties.Schema != nil {
+			p, ok = current.AdditionalProperties.Schema, true
+		}
+		if !ok {
+			msg := fmt.Sprintf("%s: unknown field", joinPath(path, k))
+			if suggestion := current.suggest(k); len(suggestion) != 0 {
+				msg = fmt.Sprintf("%s, did you mean %s?", msg, suggestion)
+			}
+			return nil, errors.New(msg)
+		}
+		path = joinPath(path, k)
+		current = p
+	}
+	return current, nil
+}
+
+func (s *Schema) validate(path string, value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+	if err := s.validateType(value); err != nil {
+		return []string{fmt.Sprintf("%s: %s", displayPath(path), err)}
+	}
+	errs := make([]string, 0)
+	if len(s.Enum) != 0 && !inEnum(s.Enum, value) {
+		enum := make([]string, len(s.Enum))
+		for i := range s.Enum {
+			enum[i] = fmt.Sprintf("%v", s.Enum[i])
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v is not one of %s", displayPath(path), value, strings.Join(enum, ", ")))
+	}
+	if n, ok := toFloat64(value); ok {
+		if s.Minimum != nil && n < *s.Minimum {
+			errs = append(errs, fmt.Sprintf("%s: %v is lower than %v", displayPath(path), n, *s.Minimum))
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			errs = append(errs, fmt.Sprintf("%s: %v is greater than %v", displayPath(path), n, *s.Maximum))
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		errs = append(errs, s.validateObject(path, v)...)
+	case []interface{}:
+		if s.Items != nil {
+			for i := range v {
+				errs = append(errs, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), v[i])...)
+			}
+		}
+	}
+	return errs
+}
+
+func (s *Schema) validateObject(path string, value map[string]interface{}) []string {
+	errs := make([]string, 0)
+	for _, r := range s.Required {
+		if v, ok := value[r]; !ok || v == nil {
+			errs = append(errs, fmt.Sprintf("%s: is missing", joinPath(path, r)))
+		}
+	}
+	keys := make([]string, 0, len(value))
+	for k := range value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if p, ok := s.Properties[k]; ok {
+			errs = append(errs, p.validate(joinPath(path, k), value[k])...)
+			continue
+		}
+		switch {
+		case s.AdditionalProperties == nil || (s.AdditionalProperties.Allowed && s.AdditionalProperties.Schema == nil):
+		case s.AdditionalProperties.Schema != nil:
+			errs = append(errs, s.AdditionalProperties.Schema.validate(joinPath(path, k), value[k])...)
+		default:
+			msg := fmt.Sprintf("%s: unknown field", joinPath(path, k))
+			if suggestion := s.suggest(k); len(suggestion) != 0 {
+				msg = fmt.Sprintf("%s, did you mean %s?", msg, suggestion)
+			}
+			errs = append(errs, msg)
+		}
+	}
+	return errs
+}
+
+func (s *Schema) validateType(value interface{}) error {
+	ok := true
+	switch s.Type {
+	case "":
+	case "object":
+		_, ok = value.(map[string]interface{})
+	case "array":
+		_, ok = value.([]interface{})
+	case "string":
+		_, ok = value.(string)
+	case "boolean":
+		_, ok = value.(bool)
+	case "number":
+		_, ok = toFloat64(value)
+	case "integer":
+		var n float64
+		n, ok = toFloat64(value)
+		ok = ok && n == math.Trunc(n)
+	default:
+		return fmt.Errorf("unsupported type %s in the schema", s.Type)
+	}
+	if !ok {
+		return fmt.Errorf("expected %s and got %v", s.Type, value)
+	}
+	return nil
+}
+
+// suggest returns the property the closest to the unknown key k
+func (s *Schema) suggest(k string) string {
+	properties := make([]string, 0, len(s.Properties))
+	for p := range s.Properties {
+		properties = append(properties, p)
+	}
+	sort.Strings(properties)
+	best := ""
+	bestDistance := 3
+	for _, p := range properties {
+		if strings.EqualFold(p, k) {
+			return p
+		}
+		if d := levenshtein(strings.ToLower(p), strings.ToLower(k)); d < bestDistance {
+			best = p
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if reflect.DeepEqual(e, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// toFloat64 converts the numbers read from a values file (float64) or set by the commands
+func toFloat64(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func joinPath(path, k string) string {
+	if len(path) == 0 {
+		return k
+	}
+	return path + "." + k
+}
+
+func displayPath(path string) string {
+	if len(path) == 0 {
+		return "values"
+	}
+	return path
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testSchema = `
+{
+  "type": "object",
+  "required": ["managedCluster"],
+  "additionalProperties": false,
+  "properties": {
+    "managedCluster": {
+      "type": "object",
+      "required": ["cloud"],
+      "additionalProperties": false,
+      "properties": {
+        "name": {"type": "string"},
+        "cloud": {"type": "string", "enum": ["aws", "gcp"]},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "worker": {
+          "type": "object",
+          "properties": {
+            "replicas": {"type": "integer", "minimum": 0}
+          }
+        },
+        "zones": {"type": "array", "items": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+func TestSchema_Validate(t *testing.T) {
+	schema := &Schema{}
+	if err := json.Unmarshal([]byte(testSchema), schema); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		errors []string
+	}{
+		{
+			name: "valid",
+			values: map[string]interface{}{
+				"managedCluster": map[string]interface{}{
+					"name":   "mycluster",
+					"cloud":  "aws",
+					"labels": map[string]interface{}{"env": "dev"},
+					"worker": map[string]interface{}{"replicas": float64(3), "unknown": true},
+					"zones":  []interface{}{"us-east-1a"},
+				},
+			},
+		},
+		{
+			name: "null values are not set",
+			values: map[string]interface{}{
+				"managedCluster": map[string]interface{}{
+					"name":  nil,
+					"cloud": "gcp",
+				},
+			},
+		},
+		{
+			name:   "missing required",
+			values: map[string]interface{}{},
+			errors: []string{"managedCluster: is missing"},
+		},
+		{
+			name: "all the errors with their path",
+			values: map[string]interface{}{
+				"managedCluster": map[string]interface{}{
+					"Name":   "mycluster",
+					"cloud":  "azure",
+					"lables": map[string]interface{}{},
+					"labels": map[string]interface{}{"env": true},
+					"worker": map[string]interface{}{"replicas": 1.5},
+					"zones":  []interface{}{"us-east-1a", 1},
+				},
+				"other": "value",
+			},
+			errors: []string{
+				"managedCluster.Name: unknown field, did you mean name?",
+				"managedCluster.cloud: azure is not one of aws, gcp",
+				"managedCluster.labels.env: expected string and got true",
+				"managedCluster.lables: unknown field, did you mean labels?",
+				"managedCluster.worker.replicas: expected integer and got 1.5",
+				"managedCluster.zones[1]: expected string and got 1",
+				"other: unknown field",
+			},
+		},
+		{
+			name: "minimum",
+			values: map[string]interface{}{
+				"managedCluster": map[string]interface{}{
+					"cloud":  "aws",
+					"worker": map[string]interface{}{"replicas": -1},
+				},
+			},
+			errors: []string{"managedCluster.worker.replicas: -1 is lower than 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.values)
+			if len(tt.errors) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %v", tt.errors)
+			}
+			got := strings.Split(err.Error(), "\n  ")[1:]
+			if strings.Join(got, "\n") != strings.Join(tt.errors, "\n") {
+				t.Errorf("got errors\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.errors, "\n"))
+			}
+		})
+	}
+}
+
+func TestSchema_Lookup(t *testing.T) {
+	schema := &Schema{}
+	if err := json.Unmarshal([]byte(testSchema), schema); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"":                               "object",
+		"managedCluster.worker.replicas": "integer",
+		"managedCluster.labels.env":      "string",
+	} {
+		s, err := schema.Lookup(path)
+		if err != nil {
+			t.Errorf("Lookup(%s) unexpected error %v", path, err)
+			continue
+		}
+		if s.Type != want {
+			t.Errorf("Lookup(%s) type = %s, want %s", path, s.Type, want)
+		}
+	}
+	if _, err := schema.Lookup("managedCluster.worker.replica"); err == nil || err.Error() != "managedCluster.worker.replica: unknown field, did you mean replicas?" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+{{- define "labels" }}
+    app: {{ .name }}
+{{- end }}
@@ -7,12 +7,35 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
 	"github.com/stolostron/applier/pkg/asset"
 )
 
 func ConvertValuesFileToValuesMap(path, prefix string) (values map[string]interface{}, err error) {
+	valuesc, err := readValuesFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveFileReferences(path, valuesc); err != nil {
+		return nil, err
+	}
+
+	values = make(map[string]interface{})
+	if prefix != "" {
+		values[prefix] = valuesc
+	} else {
+		values = valuesc
+	}
+
+	return values, nil
+}
+
+// readValuesFile reads the values file or stdin if path is empty
+func readValuesFile(path string) (values map[string]interface{}, err error) {
 	var b []byte
 	if len(path) != 0 {
 		b, err = ioutil.ReadFile(filepath.Clean(path))
@@ -34,8 +57,8 @@ func ConvertValuesFileToValuesMap(path, prefix string) (values map[string]interf
 		}
 	}
 
-	valuesc := make(map[string]interface{})
-	err = yaml.Unmarshal(b, &valuesc)
+	values = make(map[string]interface{})
+	err = yaml.Unmarshal(b, &values)
 	if err != nil {
 		if path != "" {
 			fmt.Printf("Error while unmarshaling stdin or values file %s\n", path)
@@ -44,19 +67,21 @@ func ConvertValuesFileToValuesMap(path, prefix string) (values map[string]interf
 		}
 		return nil, err
 	}
+	return values, nil
+}
 
-	values = make(map[string]interface{})
-	if prefix != "" {
-		values[prefix] = valuesc
-	} else {
-		values = valuesc
+func resolveFileReferences(path string, values map[string]interface{}) error {
+	if err := ResolveReferences(values); err != nil {
+		if path != "" {
+			return fmt.Errorf("values file %s: %v", path, err)
+		}
+		return fmt.Errorf("stdin: %v", err)
 	}
-
-	return values, nil
+	return nil
 }
 
 func ConvertReaderFileToValuesMap(path string,
-	reader *asset.ScenarioResourcesReader) (values map[string]interface{}, err error) {
+	reader asset.ScenarioReader) (values map[string]interface{}, err error) {
 	values = make(map[string]interface{})
 	b, err := reader.Asset(path)
 	if err != nil {
@@ -68,3 +93,213 @@ func ConvertReaderFileToValuesMap(path string,
 	}
 	return values, nil
 }
+
+// ValuesFlags are the values options of the commands driven by a values file.
+// The values files are deep-merged in order then the --set, --set-string and --set-file
+// overrides are applied in that order.
+type ValuesFlags struct {
+	// Paths of the values files, "-" reads stdin
+	Paths []string
+	// <dotted_path>=<value> overrides, the value is converted to a boolean, an integer or null if possible
+	Set []string
+	// <dotted_path>=<value> overrides, the value is kept as a string
+	SetString []string
+	// <dotted_path>=<file> overrides, the value is the content of the file
+	SetFile []string
+	// Base are the values under the values files (ie: a profile)
+	Base map[string]interface{}
+	// KeepReferences keeps the ${...} references of the values files unresolved to store them
+	KeepReferences bool
+	// NoStdin doesn't read stdin when no values file is given (ie: stdin is used by the --interactive mode)
+	NoStdin bool
+}
+
+// AddFlags adds the -f/--values, --set, --set-string and --set-file flags
+func (v *ValuesFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVarP(&v.Paths, "values", "f", nil,
+		"The files containing the values, can be repeated and the later files overwrite the former ones")
+	flags.StringArrayVar(&v.Set, "set", nil,
+		"Set a value on the command line (ie: managedCluster.worker.replicas=3), can be repeated")
+	flags.StringArrayVar(&v.SetString, "set-string", nil,
+		"Set a string value on the command line (ie: managedCluster.ocpImage=4.10), can be repeated")
+	flags.StringArrayVar(&v.SetFile, "set-file", nil,
+		"Set a value from the content of a file (ie: managedCluster.sshPrivateKey=~/.ssh/id_rsa), can be repeated")
+}
+
+// HasFiles returns true if at least one values file is given
+func (v *ValuesFlags) HasFiles() bool {
+	return len(v.Paths) != 0
+}
+
+// ToValuesMap reads and merges the values files, stdin if none is given, then applies the overrides
+func (v *ValuesFlags) ToValuesMap() (map[string]interface{}, error) {
+	values, err := v.ReadFiles()
+	if err != nil {
+		return nil, err
+	}
+	if err := v.ApplyOverrides(values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ReadFiles reads the values files and merges them in order over the base values,
+// stdin is read if no file is given
+func (v *ValuesFlags) ReadFiles() (map[string]interface{}, error) {
+	values := MergeValues(make(map[string]interface{}), v.Base)
+	paths := v.Paths
+	if !v.HasFiles() && !v.NoStdin {
+		paths = []string{""}
+	}
+	for _, path := range paths {
+		if path == "-" {
+			path = ""
+		}
+		layer, err := readValuesFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !v.KeepReferences {
+			if err := resolveFileReferences(path, layer); err != nil {
+				return nil, err
+			}
+		}
+		values = MergeValues(values, layer)
+	}
+	return values, nil
+}
+
+// ApplyOverrides applies the --set, --set-string and --set-file overrides on the values
+func (v *ValuesFlags) ApplyOverrides(values map[string]interface{}) error {
+	for _, s := range v.Set {
+		path, value, err := splitOverride("--set", s)
+		if err != nil {
+			return err
+		}
+		if err := setValue(values, path, parseValue(value)); err != nil {
+			return fmt.Errorf("--set %s: %v", s, err)
+		}
+	}
+	for _, s := range v.SetString {
+		path, value, err := splitOverride("--set-string", s)
+		if err != nil {
+			return err
+		}
+		if err := setValue(values, path, value); err != nil {
+			return fmt.Errorf("--set-string %s: %v", s, err)
+		}
+	}
+	for _, s := range v.SetFile {
+		path, file, err := splitOverride("--set-file", s)
+		if err != nil {
+			return err
+		}
+		file, err = expandHome(file)
+		if err != nil {
+			return fmt.Errorf("--set-file %s: %v", s, err)
+		}
+		b, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return fmt.Errorf("--set-file %s: %v", s, err)
+		}
+		if err := setValue(values, path, string(b)); err != nil {
+			return fmt.Errorf("--set-file %s: %v", s, err)
+		}
+	}
+	return nil
+}
+
+// CopyValues returns a deep copy of the maps and lists of the values
+func CopyValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	return copyValue(values).(map[string]interface{})
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k := range v {
+			c[k] = copyValue(v[k])
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i := range v {
+			c[i] = copyValue(v[i])
+		}
+		return c
+	}
+	return value
+}
+
+// MergeValues deep-merges src into dst and returns dst, the maps are merged
+// and any other value of src, including lists, replaces the one of dst.
+func MergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[k] = MergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+func splitOverride(flag, s string) (path, value string, err error) {
+	i := strings.Index(s, "=")
+	if i <= 0 {
+		return "", "", fmt.Errorf("%s %s: expected <dotted_path>=<value>", flag, s)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// parseValue converts the value of a --set to a boolean, an integer or null if possible
+func parseValue(s string) interface{} {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	return s
+}
+
+// setValue sets the value at the dotted path, the missing or null intermediate fields are created
+func setValue(values map[string]interface{}, dotedPath string, value interface{}) error {
+	fields := strings.Split(dotedPath, ".")
+	m := values
+	for i, field := range fields[:len(fields)-1] {
+		if len(field) == 0 {
+			return fmt.Errorf("empty field in %s", dotedPath)
+		}
+		switch next := m[field].(type) {
+		case map[string]interface{}:
+			m = next
+		case nil:
+			created := make(map[string]interface{})
+			m[field] = created
+			m = created
+		default:
+			return fmt.Errorf("%s is not a map", strings.Join(fields[:i+1], "."))
+		}
+	}
+	last := fields[len(fields)-1]
+	if len(last) == 0 {
+		return fmt.Errorf("empty field in %s", dotedPath)
+	}
+	m[last] = value
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValuesFlags_ToValuesMap(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "aws.yaml")
+	if err := ioutil.WriteFile(base, []byte(`managedCluster:
+  cloud: aws
+  ocpImage: quay.io/openshift-release-dev/ocp-release:4.10.3-x86_64
+  aws:
+    region: us-east-1
+    workerReplicas: 3
+    zones:
+    - us-east-1a
+    - us-east-1b
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	override := filepath.Join(dir, "mycluster.yaml")
+	if err := ioutil.WriteFile(override, []byte(`managedCluster:
+  name: mycluster
+  aws:
+    zones:
+    - us-east-1c
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sshKey := filepath.Join(dir, "id_rsa")
+	if err := ioutil.WriteFile(sshKey, []byte("private-key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	v := &ValuesFlags{
+		Paths:     []string{base, override},
+		Set:       []string{"managedCluster.aws.workerReplicas=5", "managedCluster.aws.fips=true", "managedCluster.labels.env=dev"},
+		SetString: []string{"managedCluster.aws.instanceType=10"},
+		SetFile:   []string{"managedCluster.sshPrivateKey=" + sshKey},
+	}
+	got, err := v.ToValuesMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name":          "mycluster",
+			"cloud":         "aws",
+			"ocpImage":      "quay.io/openshift-release-dev/ocp-release:4.10.3-x86_64",
+			"sshPrivateKey": "private-key",
+			"labels": map[string]interface{}{
+				"env": "dev",
+			},
+			"aws": map[string]interface{}{
+				"region":         "us-east-1",
+				"workerReplicas": int64(5),
+				"fips":           true,
+				"instanceType":   "10",
+				"zones":          []interface{}{"us-east-1c"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToValuesMap() = %v, want %v", got, want)
+	}
+}
+
+func TestValuesFlags_ApplyOverrides_setFileHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(home, ".ssh", "id_rsa"), []byte("private-key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	v := &ValuesFlags{SetFile: []string{"managedCluster.sshPrivateKey=~/.ssh/id_rsa"}}
+	values := make(map[string]interface{})
+	if err := v.ApplyOverrides(values); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := NestedString(values, "managedCluster.sshPrivateKey"); key != "private-key" {
+		t.Errorf("expected the content of ~/.ssh/id_rsa, got %q", key)
+	}
+}
+
+func TestValuesFlags_ApplyOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   ValuesFlags
+		values  map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "null intermediate field",
+			flags:  ValuesFlags{Set: []string{"managedCluster.aws.region=us-east-2"}},
+			values: map[string]interface{}{"managedCluster": map[string]interface{}{"aws": nil}},
+			want: map[string]interface{}{
+				"managedCluster": map[string]interface{}{"aws": map[string]interface{}{"region": "us-east-2"}},
+			},
+		},
+		{
+			name:   "value with equal sign and null",
+			flags:  ValuesFlags{Set: []string{"a.b=x=y", "a.c=null"}},
+			values: map[string]interface{}{},
+			want:   map[string]interface{}{"a": map[string]interface{}{"b": "x=y", "c": nil}},
+		},
+		{
+			name:    "missing value",
+			flags:   ValuesFlags{Set: []string{"managedCluster.name"}},
+			values:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:    "not a map",
+			flags:   ValuesFlags{SetString: []string{"managedCluster.name.first=a"}},
+			values:  map[string]interface{}{"managedCluster": map[string]interface{}{"name": "mycluster"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			flags:   ValuesFlags{SetFile: []string{"managedCluster.sshPrivateKey=/does/not/exist"}},
+			values:  map[string]interface{}{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flags.ApplyOverrides(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.values, tt.want) {
+				t.Errorf("ApplyOverrides() = %v, want %v", tt.values, tt.want)
+			}
+		})
+	}
+}
+
+func TestValuesFlags_Base(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "values.yaml")
+	if err := ioutil.WriteFile(path, []byte("managedCluster:\n  name: mycluster\n  aws:\n    awsSecretAccessKeyID: ${env:CM_TEST_NOT_SET}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	v := &ValuesFlags{
+		Paths: []string{path},
+		Set:   []string{"managedCluster.aws.region=us-east-2"},
+		Base: map[string]interface{}{
+			"managedCluster": map[string]interface{}{
+				"name":  "profile",
+				"cloud": "aws",
+				"aws":   map[string]interface{}{"region": "us-east-1"},
+			},
+		},
+		KeepReferences: true,
+	}
+	got, err := v.ToValuesMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name":  "mycluster",
+			"cloud": "aws",
+			"aws": map[string]interface{}{
+				"region":               "us-east-2",
+				"awsSecretAccessKeyID": "${env:CM_TEST_NOT_SET}",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToValuesMap() = %v, want %v", got, want)
+	}
+}
+
+func TestCopyValues(t *testing.T) {
+	values := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"pullSecret": "${env:PULL_SECRET}",
+			"labels":     []interface{}{map[string]interface{}{"env": "dev"}},
+		},
+	}
+	c := CopyValues(values)
+	if !reflect.DeepEqual(c, values) {
+		t.Fatalf("expected %v and got %v", values, c)
+	}
+	c["managedCluster"].(map[string]interface{})["pullSecret"] = "resolved"
+	c["managedCluster"].(map[string]interface{})["labels"].([]interface{})[0].(map[string]interface{})["env"] = "prod"
+	if values["managedCluster"].(map[string]interface{})["pullSecret"] != "${env:PULL_SECRET}" {
+		t.Errorf("the copy of a map must not change the values")
+	}
+	if values["managedCluster"].(map[string]interface{})["labels"].([]interface{})[0].(map[string]interface{})["env"] != "dev" {
+		t.Errorf("the copy of a list must not change the values")
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"golang.org/x/term"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// Prompt is the x-prompt extension of a schema property, it turns the property into a question of the --interactive mode
+type Prompt struct {
+	// Order of the question, the properties without order are not asked
+	Order int `json:"order,omitempty"`
+	// When is a <dotted_path>=<value> condition on the previous answers, it applies to the sub-properties
+	When string `json:"when,omitempty"`
+	// SkipWith is a dotted path which makes the question useless once answered, it applies to the sub-properties
+	SkipWith string `json:"skipWith,omitempty"`
+	// Choices is the name of a list of choices computed by the command (ie: clusterimagesets, credentials),
+	// the question is skipped if the list is empty
+	Choices string `json:"choices,omitempty"`
+	// Secret hides the answer on a terminal
+	Secret bool `json:"secret,omitempty"`
+	// Hint is displayed with the description
+	Hint string `json:"hint,omitempty"`
+}
+
+// Question is a property of the schema to ask
+type Question struct {
+	Path     string
+	Schema   *Schema
+	When     []string
+	SkipWith []string
+}
+
+// Questions returns the properties having an x-prompt order, sorted by order
+func (s *Schema) Questions() []Question {
+	questions := s.questions("", nil, nil)
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Schema.Prompt.Order < questions[j].Schema.Prompt.Order
+	})
+	return questions
+}
+
+func (s *Schema) questions(path string, when, skipWith []string) []Question {
+	if s.Prompt != nil {
+		if len(s.Prompt.When) != 0 {
+			when = append(append([]string{}, when...), s.Prompt.When)
+		}
+		if len(s.Prompt.SkipWith) != 0 {
+			skipWith = append(append([]string{}, skipWith...), s.Prompt.SkipWith)
+		}
+	}
+	questions := make([]Question, 0)
+	if s.Prompt != nil && s.Prompt.Order != 0 {
+		questions = append(questions, Question{Path: path, Schema: s, When: when, SkipWith: skipWith})
+	}
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		questions = append(questions, s.Properties[k].questions(joinPath(path, k), when, skipWith)...)
+	}
+	return questions
+}
+
+// ChoicesFunc returns the choices of a question from the current values
+type ChoicesFunc func(values map[string]interface{}) ([]string, error)
+
+// Wizard asks the questions of a schema and sets the answers in the values
+type Wizard struct {
+	in      *bufio.Reader
+	inFile  *os.File
+	out     io.Writer
+	choices map[string]ChoicesFunc
+}
+
+// NewWizard returns a wizard reading the answers from in and using the choices functions for the x-prompt choices
+func NewWizard(in io.Reader, out io.Writer, choices map[string]ChoicesFunc) *Wizard {
+	w := &Wizard{
+		in:      bufio.NewReader(in),
+		out:     out,
+		choices: choices,
+	}
+	if f, ok := in.(*os.File); ok {
+		w.inFile = f
+	}
+	return w
+}
+
+// Run asks the questions of the schema which are not already answered in the values,
+// each answer is validated against the schema of its property before the next question.
+func (w *Wizard) Run(schema *Schema, values map[string]interface{}) error {
+	for _, q := range schema.Questions() {
+		if !w.isAsked(q, values) {
+			continue
+		}
+		choices, err := w.getChoices(q, values)
+		if err != nil {
+			fmt.Fprintf(w.out, "\nunable to list the choices of %s, the question is skipped: %v\n", q.Path, err)
+			continue
+		}
+		if len(q.Schema.Prompt.Choices) != 0 && len(choices) == 0 {
+			continue
+		}
+		value, err := w.ask(q, choices)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			continue
+		}
+		if err := setValue(values, q.Path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Wizard) isAsked(q Question, values map[string]interface{}) bool {
+	if isSet(values, q.Path) {
+		return false
+	}
+	for _, when := range q.When {
+		i := strings.Index(when, "=")
+		if i < 0 {
+			continue
+		}
+		if v, _ := NestedString(values, when[:i]); v != when[i+1:] {
+			return false
+		}
+	}
+	for _, skipWith := range q.SkipWith {
+		if isSet(values, skipWith) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSet(values map[string]interface{}, dotedPath string) bool {
+	m := values
+	fields := strings.Split(dotedPath, ".")
+	for _, field := range fields[:len(fields)-1] {
+		next, ok := m[field].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		m = next
+	}
+	v, ok := m[fields[len(fields)-1]]
+	if !ok || v == nil {
+		return false
+	}
+	s, ok := v.(string)
+	return !ok || len(s) != 0
+}
+
+func (w *Wizard) getChoices(q Question, values map[string]interface{}) ([]string, error) {
+	if len(q.Schema.Prompt.Choices) != 0 {
+		f, ok := w.choices[q.Schema.Prompt.Choices]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown choices %s", q.Path, q.Schema.Prompt.Choices)
+		}
+		return f(values)
+	}
+	choices := make([]string, 0, len(q.Schema.Enum))
+	for _, e := range q.Schema.Enum {
+		choices = append(choices, fmt.Sprintf("%v", e))
+	}
+	return choices, nil
+}
+
+// ask asks the question until the answer is valid, a nil value is returned if the question is skipped
+func (w *Wizard) ask(q Question, choices []string) (interface{}, error) {
+	description := q.Schema.Description
+	if len(q.Schema.Prompt.Hint) != 0 {
+		description = strings.TrimSpace(fmt.Sprintf("%s (%s)", description, q.Schema.Prompt.Hint))
+	}
+	for {
+		fmt.Fprintf(w.out, "\n%s: %s\n", q.Path, description)
+		for i, c := range choices {
+			fmt.Fprintf(w.out, "  %d) %s\n", i+1, c)
+		}
+		if q.Schema.Default != nil {
+			fmt.Fprintf(w.out, "[%v]? ", q.Schema.Default)
+		} else {
+			fmt.Fprint(w.out, "? ")
+		}
+		answer, err := w.readLine(q.Schema.Prompt.Secret)
+		if err != nil {
+			return nil, err
+		}
+		if len(answer) == 0 {
+			return q.Schema.Default, nil
+		}
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(choices) && q.Schema.Type != "integer" {
+			answer = choices[i-1]
+		}
+		value, err := convertAnswer(q.Schema.Type, answer)
+		if err != nil {
+			fmt.Fprintf(w.out, "%s: %v\n", q.Path, err)
+			continue
+		}
+		if errs := q.Schema.validate(q.Path, value); len(errs) != 0 {
+			fmt.Fprintln(w.out, strings.Join(errs, "\n"))
+			continue
+		}
+		return value, nil
+	}
+}
+
+func (w *Wizard) readLine(secret bool) (string, error) {
+	if secret && w.inFile != nil && term.IsTerminal(int(w.inFile.Fd())) {
+		b, err := term.ReadPassword(int(w.inFile.Fd()))
+		fmt.Fprintln(w.out)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	line, err := w.in.ReadString('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		if err == io.EOF {
+			return "", fmt.Errorf("the interactive input ended before all questions were answered")
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func convertAnswer(schemaType, answer string) (interface{}, error) {
+	switch schemaType {
+	case "integer":
+		return strconv.ParseInt(answer, 10, 64)
+	case "number":
+		return strconv.ParseFloat(answer, 64)
+	case "boolean":
+		return strconv.ParseBool(answer)
+	case "array":
+		items := make([]interface{}, 0)
+		for _, item := range strings.Split(answer, ",") {
+			if item = strings.TrimSpace(item); len(item) != 0 {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+	return answer, nil
+}
+
+// Confirm asks a yes/no question
+func (w *Wizard) Confirm(question string, def bool) (bool, error) {
+	options := "[Y/n]"
+	if !def {
+		options = "[y/N]"
+	}
+	for {
+		fmt.Fprintf(w.out, "\n%s %s? ", question, options)
+		answer, err := w.readLine(false)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// WriteValues asks for a file and writes the values in it for reuse with --values
+func (w *Wizard) WriteValues(values map[string]interface{}) error {
+	fmt.Fprint(w.out, "\nFile to write the values to, for reuse with --values (empty to skip)? ")
+	path, err := w.readLine(false)
+	if err != nil || len(path) == 0 {
+		return err
+	}
+	b, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Clean(path), b, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "values written to %s\n", path)
+	return nil
+}
+
+// ClusterImageSetChoices lists the clusterimagesets of the hub
+func ClusterImageSetChoices(dynamicClient dynamic.Interface) ChoicesFunc {
+	return func(values map[string]interface{}) ([]string, error) {
+		l, err := dynamicClient.Resource(GvrCIS).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(l.Items))
+		for _, cis := range l.Items {
+			names = append(names, cis.GetName())
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const wizardSchema = `{
+  "type": "object",
+  "properties": {
+    "credentials": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "x-prompt": {"order": 30, "choices": "credentials"}}
+      }
+    },
+    "managedCluster": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "x-prompt": {"order": 10}},
+        "cloud": {"type": "string", "enum": ["aws", "gcp"], "default": "aws", "x-prompt": {"order": 20}},
+        "imageSetRef": {"type": "string", "x-prompt": {"order": 80, "choices": "clusterimagesets"}},
+        "worker": {
+          "type": "object",
+          "properties": {
+            "replicas": {"type": "integer", "minimum": 1, "default": 3, "x-prompt": {"order": 90}}
+          }
+        },
+        "aws": {
+          "type": "object",
+          "x-prompt": {"when": "managedCluster.cloud=aws"},
+          "properties": {
+            "region": {"type": "string", "x-prompt": {"order": 40}},
+            "awsAccessKeyID": {"type": "string", "x-prompt": {"order": 60, "skipWith": "credentials.name"}}
+          }
+        },
+        "gcp": {
+          "type": "object",
+          "x-prompt": {"when": "managedCluster.cloud=gcp"},
+          "properties": {
+            "region": {"type": "string", "x-prompt": {"order": 40}}
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestWizard_Run(t *testing.T) {
+	schema := &Schema{}
+	if err := json.Unmarshal([]byte(wizardSchema), schema); err != nil {
+		t.Fatal(err)
+	}
+	choices := map[string]ChoicesFunc{
+		"credentials": func(values map[string]interface{}) ([]string, error) {
+			return []string{"aws-creds"}, nil
+		},
+		"clusterimagesets": func(values map[string]interface{}) ([]string, error) {
+			return []string{}, nil
+		},
+	}
+	values := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name": "mycluster",
+		},
+	}
+	// cloud: default, credentials: first choice, region, replicas: invalid then valid
+	in := strings.NewReader("\n1\nus-east-1\n0\nfive\n5\n")
+	out := &bytes.Buffer{}
+	if err := NewWizard(in, out, choices).Run(schema, values); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"credentials": map[string]interface{}{
+			"name": "aws-creds",
+		},
+		"managedCluster": map[string]interface{}{
+			"name":   "mycluster",
+			"cloud":  "aws",
+			"aws":    map[string]interface{}{"region": "us-east-1"},
+			"worker": map[string]interface{}{"replicas": int64(5)},
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Run() = %v, want %v", values, want)
+	}
+	for _, s := range []string{"managedCluster.worker.replicas: 0 is lower than 1", "invalid syntax"} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("output %q doesn't contain %q", out.String(), s)
+		}
+	}
+	if strings.Contains(out.String(), "managedCluster.name:") || strings.Contains(out.String(), "awsAccessKeyID") {
+		t.Errorf("answered or skipped questions were asked:\n%s", out.String())
+	}
+
+	if err := NewWizard(strings.NewReader(""), out, choices).Run(schema, map[string]interface{}{}); err == nil {
+		t.Error("expected an error when the input ends")
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	genericclioptionscm "github.com/stolostron/cm-cli/pkg/genericclioptions"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// machinePoolTemplatePath is the template of the worker machinepool of cm create cluster,
+// it renders the machinepool described by the machinePool values if set
+var machinePoolTemplatePath = filepath.Join("create", "hub", "common", "machinepool_cr.yaml")
+
+// Target is the hive cluster hosting the clusterdeployment of a cluster
+type Target struct {
+	RestConfig *rest.Config
+	// ClusterDeploymentName is also the namespace of the clusterdeployment and its machinepools
+	ClusterDeploymentName string
+}
+
+// MachinePool describes a machinepool to create
+type MachinePool struct {
+	// Name of the pool, the name of the machinepool resource is <clusterdeployment>-<name>
+	Name string
+	// InstanceType overwrites the instance type or the flavor of the platform
+	InstanceType string
+	// Zones overwrites the zones of the platform
+	Zones    []string
+	Labels   map[string]string
+	Taints   []corev1.Taint
+	Replicas int64
+	// MinReplicas and MaxReplicas enable the autoscaling when set
+	MinReplicas int32
+	MaxReplicas int32
+}
+
+// NewTarget returns the hive cluster hosting the machinepools of clusterName.
+// When clusterPoolHostName is set or clusterClaim is true, clusterName is a clusterclaim of
+// the clusterpoolhost (the active one if clusterPoolHostName is empty), otherwise it is a cluster of the hub.
+func NewTarget(cmFlags *genericclioptionscm.CMFlags, clusterName, clusterPoolHostName string, clusterClaim bool) (*Target, error) {
+	if len(clusterPoolHostName) == 0 && !clusterClaim {
+		restConfig, err := cmFlags.KubectlFactory.ToRESTConfig()
+		if err != nil {
+			return nil, err
+		}
+		return &Target{RestConfig: restConfig, ClusterDeploymentName: clusterName}, nil
+	}
+	cph, err := clusterpoolhost.GetClusterPoolHostOrCurrent(clusterPoolHostName)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := cph.GetClusterClaim(clusterName, false, 0, cmFlags.DryRun, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(cc.Spec.Namespace) == 0 {
+		return nil, fmt.Errorf("the clusterclaim %s is not yet assigned to a cluster", clusterName)
+	}
+	restConfig, err := cph.GetGlobalRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &Target{RestConfig: restConfig, ClusterDeploymentName: cc.Spec.Namespace}, nil
+}
+
+// Create creates a machinepool, the platform is copied from the worker machinepool of the cluster
+// with the instance type and the zones overwritten if set.
+func (t *Target) Create(mp *MachinePool, dryRun bool, outputFile string) error {
+	dynamicClient, err := dynamic.NewForConfig(t.RestConfig)
+	if err != nil {
+		return err
+	}
+	mps, err := list(dynamicClient, t.ClusterDeploymentName)
+	if err != nil {
+		return err
+	}
+	for _, p := range mps {
+		if p.Spec.Name == mp.Name {
+			return fmt.Errorf("machinepool %s already exists for cluster %s", mp.Name, t.ClusterDeploymentName)
+		}
+	}
+	base := basePlatform(mps)
+	if base == nil {
+		return fmt.Errorf("no machinepool found for cluster %s to copy the platform from", t.ClusterDeploymentName)
+	}
+	values, err := mp.values(t.ClusterDeploymentName, base)
+	if err != nil {
+		return err
+	}
+	reader := scenario.GetScenarioResourcesReader()
+	applier := apply.NewApplierBuilder().WithRestConfig(t.RestConfig).Build()
+	output, err := applier.ApplyCustomResources(reader, values, dryRun, "", machinePoolTemplatePath)
+	if err != nil {
+		return err
+	}
+	return apply.WriteOutput(outputFile, output)
+}
+
+// Delete deletes a machinepool
+func (t *Target) Delete(name string, dryRun bool) error {
+	dynamicClient, err := dynamic.NewForConfig(t.RestConfig)
+	if err != nil {
+		return err
+	}
+	mp, err := get(dynamicClient, t.ClusterDeploymentName, name)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	return dynamicClient.Resource(helpers.GvrMP).Namespace(mp.Namespace).Delete(context.TODO(), mp.Name, metav1.DeleteOptions{})
+}
+
+// Scale sets the replicas of a machinepool or, if maxReplicas is set, switches it to autoscaling
+func (t *Target) Scale(name string, replicas int64, minReplicas, maxReplicas int32, dryRun bool) error {
+	dynamicClient, err := dynamic.NewForConfig(t.RestConfig)
+	if err != nil {
+		return err
+	}
+	mp, err := get(dynamicClient, t.ClusterDeploymentName, name)
+	if err != nil {
+		return err
+	}
+	setScale(mp, replicas, minReplicas, maxReplicas)
+	if dryRun {
+		return nil
+	}
+	mpu, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mp)
+	if err != nil {
+		return err
+	}
+	_, err = dynamicClient.Resource(helpers.GvrMP).Namespace(mp.Namespace).Update(context.TODO(), &unstructured.Unstructured{Object: mpu}, metav1.UpdateOptions{})
+	return err
+}
+
+func setScale(mp *hivev1.MachinePool, replicas int64, minReplicas, maxReplicas int32) {
+	if maxReplicas != 0 {
+		mp.Spec.Replicas = nil
+		mp.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
+		}
+		return
+	}
+	mp.Spec.Autoscaling = nil
+	mp.Spec.Replicas = &replicas
+}
+
+// ValidateAutoscaling checks the bounds of the autoscaling
+func ValidateAutoscaling(minReplicas, maxReplicas int32) error {
+	if maxReplicas <= 0 {
+		return fmt.Errorf("--max must be greater than 0 to enable the autoscaling")
+	}
+	if minReplicas < 0 || minReplicas > maxReplicas {
+		return fmt.Errorf("--min must be between 0 and --max")
+	}
+	return nil
+}
+
+// ParseTaint parses a taint in the kubectl format <key>[=<value>]:<effect>
+func ParseTaint(s string) (corev1.Taint, error) {
+	t := corev1.Taint{}
+	i := strings.LastIndex(s, ":")
+	if i <= 0 {
+		return t, fmt.Errorf("invalid taint %s, the format is <key>[=<value>]:<effect>", s)
+	}
+	t.Effect = corev1.TaintEffect(s[i+1:])
+	switch t.Effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return t, fmt.Errorf("invalid taint effect %s, supported effects are %s, %s and %s",
+			t.Effect, corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute)
+	}
+	kv := strings.SplitN(s[:i], "=", 2)
+	t.Key = kv[0]
+	if len(kv) == 2 {
+		t.Value = kv[1]
+	}
+	if len(t.Key) == 0 {
+		return t, fmt.Errorf("invalid taint %s, the key is missing", s)
+	}
+	return t, nil
+}
+
+// values returns the values of the machinepool template of cm create cluster, the platform
+// is given as the one of the install-config compute pool or as the openstack flavor
+func (mp *MachinePool) values(clusterDeploymentName string, base *hivev1.MachinePoolPlatform) (map[string]interface{}, error) {
+	platform, err := mp.platform(base)
+	if err != nil {
+		return nil, err
+	}
+	managedCluster := map[string]interface{}{
+		"name": clusterDeploymentName,
+	}
+	for cloud, ic := range platform {
+		managedCluster["cloud"] = cloud
+		if c, ok := ic.(map[string]interface{}); ok && cloud == "openstack" {
+			managedCluster["openstack"] = map[string]interface{}{
+				"workerFlavor": c["flavor"],
+			}
+		}
+	}
+	mpValues := map[string]interface{}{
+		"name": mp.Name,
+	}
+	if mp.MaxReplicas != 0 {
+		mpValues["autoscaling"] = map[string]interface{}{
+			"minReplicas": mp.MinReplicas,
+			"maxReplicas": mp.MaxReplicas,
+		}
+	}
+	if len(mp.Labels) != 0 {
+		mpValues["labels"] = mp.Labels
+	}
+	if len(mp.Taints) != 0 {
+		taints := make([]interface{}, 0)
+		for _, t := range mp.Taints {
+			taint := map[string]interface{}{
+				"key":    t.Key,
+				"effect": string(t.Effect),
+			}
+			if len(t.Value) != 0 {
+				taint["value"] = t.Value
+			}
+			taints = append(taints, taint)
+		}
+		mpValues["taints"] = taints
+	}
+	return map[string]interface{}{
+		"managedCluster": managedCluster,
+		"installConfig": map[string]interface{}{
+			"compute": []interface{}{
+				map[string]interface{}{
+					"platform": platform,
+					"replicas": mp.Replicas,
+				},
+			},
+		},
+		"machinePool": mpValues,
+	}, nil
+}
+
+// platform returns the base platform with the instance type and the zones overwritten
+func (mp *MachinePool) platform(base *hivev1.MachinePoolPlatform) (map[string]interface{}, error) {
+	p, err := runtime.DefaultUnstructuredConverter.ToUnstructured(base.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	for cloud, ic := range p {
+		c, ok := ic.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if len(mp.InstanceType) != 0 {
+			switch cloud {
+			case "aws", "azure", "gcp":
+				c["type"] = mp.InstanceType
+			case "openstack":
+				c["flavor"] = mp.InstanceType
+			default:
+				return nil, fmt.Errorf("the instance type can not be set on %s", cloud)
+			}
+		}
+		if len(mp.Zones) != 0 {
+			switch cloud {
+			case "aws", "azure", "gcp", "ibmcloud":
+				zones := make([]interface{}, len(mp.Zones))
+				for i := range mp.Zones {
+					zones[i] = mp.Zones[i]
+				}
+				c["zones"] = zones
+			default:
+				return nil, fmt.Errorf("the zones can not be set on %s", cloud)
+			}
+		}
+	}
+	return p, nil
+}
+
+// basePlatform returns the platform of the worker machinepool or of the first one
+func basePlatform(mps []hivev1.MachinePool) *hivev1.MachinePoolPlatform {
+	for i := range mps {
+		if mps[i].Spec.Name == "worker" {
+			return &mps[i].Spec.Platform
+		}
+	}
+	if len(mps) != 0 {
+		return &mps[0].Spec.Platform
+	}
+	return nil
+}
+
+func list(dynamicClient dynamic.Interface, clusterDeploymentName string) ([]hivev1.MachinePool, error) {
+	mpus, err := dynamicClient.Resource(helpers.GvrMP).Namespace(clusterDeploymentName).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	mps := make([]hivev1.MachinePool, 0)
+	for _, mpu := range mpus.Items {
+		mp := hivev1.MachinePool{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(mpu.UnstructuredContent(), &mp); err != nil {
+			return nil, err
+		}
+		if mp.Spec.ClusterDeploymentRef.Name != clusterDeploymentName {
+			continue
+		}
+		mps = append(mps, mp)
+	}
+	return mps, nil
+}
+
+// get returns the machinepool having name as pool name or resource name
+func get(dynamicClient dynamic.Interface, clusterDeploymentName, name string) (*hivev1.MachinePool, error) {
+	mps, err := list(dynamicClient, clusterDeploymentName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mps {
+		if mps[i].Spec.Name == name || mps[i].Name == name {
+			return &mps[i], nil
+		}
+	}
+	return nil, fmt.Errorf("machinepool %s not found for cluster %s", name, clusterDeploymentName)
+}
@@ -0,0 +1,168 @@
+// Copyright Contributors to the Open Cluster Management project
+package machinepool
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	"github.com/stolostron/applier/pkg/apply"
+	"github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseTaint(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    corev1.Taint
+		wantErr bool
+	}{
+		{in: "dedicated=infra:NoSchedule", want: corev1.Taint{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule}},
+		{in: "node-role.kubernetes.io/infra:NoExecute", want: corev1.Taint{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoExecute}},
+		{in: "dedicated=infra", wantErr: true},
+		{in: "dedicated=infra:Never", wantErr: true},
+		{in: "=infra:NoSchedule", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTaint(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTaint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseTaint() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	mps := []hivev1.MachinePool{
+		{
+			Spec: hivev1.MachinePoolSpec{
+				Name: "infra",
+				Platform: hivev1.MachinePoolPlatform{
+					AWS: &hivev1aws.MachinePoolPlatform{InstanceType: "m5.large"},
+				},
+			},
+		},
+		{
+			Spec: hivev1.MachinePoolSpec{
+				Name: "worker",
+				Platform: hivev1.MachinePoolPlatform{
+					AWS: &hivev1aws.MachinePoolPlatform{
+						InstanceType: "m5.xlarge",
+						Zones:        []string{"us-east-1a"},
+						EC2RootVolume: hivev1aws.EC2RootVolume{
+							Size: 100,
+							Type: "gp3",
+						},
+					},
+				},
+			},
+		},
+	}
+	mp := &MachinePool{
+		Name:         "gpu",
+		InstanceType: "p3.2xlarge",
+		Zones:        []string{"us-east-1b", "us-east-1c"},
+		Labels:       map[string]string{"gpu": "true"},
+		Taints:       []corev1.Taint{{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+		MinReplicas:  1,
+		MaxReplicas:  4,
+	}
+	values, err := mp.values("mycluster", basePlatform(mps))
+	if err != nil {
+		t.Fatal(err)
+	}
+	applier := apply.NewApplierBuilder().Build()
+	b, err := applier.MustTemplateAsset(scenario.GetScenarioResourcesReader(), values, "", machinePoolTemplatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &hivev1.MachinePool{}
+	if err := yaml.Unmarshal(b, got); err != nil {
+		t.Fatalf("%v\n%s", err, string(b))
+	}
+	if got.Name != "mycluster-gpu" || got.Namespace != "mycluster" ||
+		got.Spec.ClusterDeploymentRef.Name != "mycluster" || got.Spec.Name != "gpu" {
+		t.Errorf("unexpected metadata or reference\n%s", string(b))
+	}
+	aws := got.Spec.Platform.AWS
+	if aws == nil || aws.InstanceType != "p3.2xlarge" || len(aws.Zones) != 2 || aws.EC2RootVolume.Size != 100 {
+		t.Errorf("unexpected platform\n%s", string(b))
+	}
+	if got.Spec.Replicas != nil || got.Spec.Autoscaling == nil ||
+		got.Spec.Autoscaling.MinReplicas != 1 || got.Spec.Autoscaling.MaxReplicas != 4 {
+		t.Errorf("unexpected scaling\n%s", string(b))
+	}
+	if got.Spec.Labels["gpu"] != "true" || len(got.Spec.Taints) != 1 || got.Spec.Taints[0] != mp.Taints[0] {
+		t.Errorf("unexpected labels or taints\n%s", string(b))
+	}
+	// the base platform must not be modified
+	if mps[1].Spec.Platform.AWS.InstanceType != "m5.xlarge" {
+		t.Error("the base platform was modified")
+	}
+}
+
+func TestCreateTemplateOpenstack(t *testing.T) {
+	tests := []struct {
+		name         string
+		flavor       string
+		instanceType string
+		want         string
+	}{
+		{name: "flavor of the worker pool", flavor: "m1.large", want: "m1.large"},
+		{name: "instance type", flavor: "m1.large", instanceType: "m1.2xlarge", want: "m1.2xlarge"},
+		{name: "default flavor of the template", want: "m1.xlarge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := &hivev1.MachinePoolPlatform{OpenStack: &hivev1openstack.MachinePool{Flavor: tt.flavor}}
+			values, err := (&MachinePool{Name: "infra", InstanceType: tt.instanceType, Replicas: 2}).values("mycluster", base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := apply.NewApplierBuilder().Build().MustTemplateAsset(scenario.GetScenarioResourcesReader(), values, "", machinePoolTemplatePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &hivev1.MachinePool{}
+			if err := yaml.Unmarshal(b, got); err != nil {
+				t.Fatalf("%v\n%s", err, string(b))
+			}
+			if got.Spec.Platform.OpenStack == nil || got.Spec.Platform.OpenStack.Flavor != tt.want {
+				t.Errorf("expected the flavor %s\n%s", tt.want, string(b))
+			}
+			if got.Spec.Replicas == nil || *got.Spec.Replicas != 2 {
+				t.Errorf("expected 2 replicas\n%s", string(b))
+			}
+		})
+	}
+}
+
+func TestPlatformUnsupportedOverride(t *testing.T) {
+	base := &hivev1.MachinePoolPlatform{VSphere: &hivev1vsphere.MachinePool{NumCPUs: 4}}
+	if _, err := (&MachinePool{InstanceType: "large"}).platform(base); err == nil {
+		t.Error("expected an error when setting an instance type on vsphere")
+	}
+	if _, err := (&MachinePool{}).platform(base); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestSetScale(t *testing.T) {
+	mp := &hivev1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "mycluster-worker"}}
+	setScale(mp, 3, 2, 5)
+	if mp.Spec.Replicas != nil || mp.Spec.Autoscaling == nil || mp.Spec.Autoscaling.MaxReplicas != 5 {
+		t.Errorf("expected autoscaling, got %+v", mp.Spec)
+	}
+	setScale(mp, 3, 0, 0)
+	if mp.Spec.Autoscaling != nil || mp.Spec.Replicas == nil || *mp.Spec.Replicas != 3 {
+		t.Errorf("expected replicas, got %+v", mp.Spec)
+	}
+}
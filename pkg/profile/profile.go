@@ -0,0 +1,194 @@
+// Copyright Contributors to the Open Cluster Management project
+package profile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/stolostron/cm-cli/pkg/clusterpoolhost"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+)
+
+// ProfilesDir is the directory, relative to the home directory, where the profiles are stored
+// next to the other cm files such as the known clusterpoolhosts
+var ProfilesDir = filepath.Join(clusterpoolhost.ClusterPoolHostsDir, "cm-profiles")
+
+const profileExtension = ".yaml"
+
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`)
+
+// clusterFields are the fields of a 'cm get config cluster' output specific to a cluster or holding secrets,
+// they are removed when the output is imported as a profile.
+var clusterFields = []string{
+	"credentials",
+	"managedCluster.name",
+	"managedCluster.pullSecret",
+	"managedCluster.sshPrivateKey",
+	"managedCluster.sshPublicKey",
+	"managedCluster.aws.awsAccessKeyID",
+	"managedCluster.aws.awsSecretAccessKeyID",
+	"managedCluster.azure.clientID",
+	"managedCluster.azure.clientSecret",
+	"managedCluster.azure.tenantID",
+	"managedCluster.azure.subscriptionID",
+	"managedCluster.gcp.osServiceAccountJson",
+	"managedCluster.vsphere.username",
+	"managedCluster.vsphere.password",
+	"managedCluster.vsphere.cacertificate",
+	"managedCluster.openstack.cloudsYaml",
+}
+
+// Profile is a named values fragment stored locally
+type Profile struct {
+	Name   string
+	Values map[string]interface{}
+}
+
+// ValidateName returns an error if the name can not be used as a profile name
+func ValidateName(name string) error {
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q, it must contain only alphanumeric characters, '-', '_' or '.'", name)
+	}
+	return nil
+}
+
+func getPath(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(filepath.Join(home, ProfilesDir, name+profileExtension)), nil
+}
+
+// Get returns the profile, its ${...} references are not resolved
+func Get(name string) (*Profile, error) {
+	path, err := getPath(name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("profile %s not found", name)
+		}
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("unable to parse the profile %s: %v", name, err)
+	}
+	return &Profile{Name: name, Values: values}, nil
+}
+
+// GetValues returns the values of the profile with its ${...} references resolved
+func GetValues(name string) (map[string]interface{}, error) {
+	p, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := helpers.ResolveReferences(p.Values); err != nil {
+		return nil, fmt.Errorf("profile %s: %v", name, err)
+	}
+	return p.Values, nil
+}
+
+// List returns the profiles sorted by name
+func List() ([]*Profile, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(filepath.Join(home, ProfilesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Profile{}, nil
+		}
+		return nil, err
+	}
+	profiles := make([]*Profile, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != profileExtension {
+			continue
+		}
+		p, err := Get(strings.TrimSuffix(f.Name(), profileExtension))
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// Save stores the profile, an existing profile is replaced only if overwrite is set
+func (p *Profile) Save(overwrite bool) error {
+	path, err := getPath(p.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("profile %s already exists", p.Name)
+	}
+	b, err := yaml.Marshal(p.Values)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// Delete removes the profile
+func Delete(name string) error {
+	path, err := getPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("profile %s not found", name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Sections returns the top level keys of the values of the profile (ie: managedCluster, clusterPool)
+func (p *Profile) Sections() []string {
+	sections := make([]string, 0, len(p.Values))
+	for k := range p.Values {
+		sections = append(sections, k)
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// RemoveClusterFields removes from the values of a 'cm get config cluster' output
+// the name of the cluster and its secrets to keep only its shape
+func RemoveClusterFields(values map[string]interface{}) {
+	for _, f := range clusterFields {
+		fields := strings.Split(f, ".")
+		m := values
+		for _, field := range fields[:len(fields)-1] {
+			next, ok := m[field].(map[string]interface{})
+			if !ok {
+				m = nil
+				break
+			}
+			m = next
+		}
+		if m != nil {
+			delete(m, fields[len(fields)-1])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright Contributors to the Open Cluster Management project
+package profile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CM_TEST_REGION", "us-east-1")
+
+	profiles, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("expected no profile, got %v", profiles)
+	}
+
+	p := &Profile{
+		Name: "aws-small-us-east",
+		Values: map[string]interface{}{
+			"managedCluster": map[string]interface{}{
+				"cloud": "aws",
+				"aws": map[string]interface{}{
+					"region": "${env:CM_TEST_REGION}",
+				},
+			},
+		},
+	}
+	if err := p.Save(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Save(false); err == nil {
+		t.Error("expected an error when saving an existing profile without overwrite")
+	}
+	if err := p.Save(true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Get(p.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("Get() = %v, want %v", got, p)
+	}
+	values, err := GetValues(p.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region := values["managedCluster"].(map[string]interface{})["aws"].(map[string]interface{})["region"]; region != "us-east-1" {
+		t.Errorf("GetValues() must resolve the references, got region %v", region)
+	}
+
+	profiles, err = List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != p.Name || !reflect.DeepEqual(profiles[0].Sections(), []string{"managedCluster"}) {
+		t.Errorf("unexpected profiles %v", profiles)
+	}
+
+	if err := Delete(p.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get(p.Name); err == nil {
+		t.Error("expected an error when getting a deleted profile")
+	}
+	if err := Delete(p.Name); err == nil {
+		t.Error("expected an error when deleting a missing profile")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"aws-small-us-east", "gcp_perf", "v4.10"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%s) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "../aws", "aws/small", "-aws"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%s) expected an error", name)
+		}
+	}
+}
+
+func TestRemoveClusterFields(t *testing.T) {
+	values := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"name":          "mycluster",
+			"cloud":         "aws",
+			"sshPrivateKey": "private-key",
+			"aws": map[string]interface{}{
+				"region":               "us-east-1",
+				"awsAccessKeyID":       "id",
+				"awsSecretAccessKeyID": "key",
+			},
+		},
+	}
+	RemoveClusterFields(values)
+	want := map[string]interface{}{
+		"managedCluster": map[string]interface{}{
+			"cloud": "aws",
+			"aws": map[string]interface{}{
+				"region": "us-east-1",
+			},
+		},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("RemoveClusterFields() = %v, want %v", values, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+package scenarios
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	clusterpoolhostscenario "github.com/stolostron/cm-cli/pkg/clusterpoolhost/scenario"
+	attachclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/attach/cluster/scenario"
+	authrealmscenario "github.com/stolostron/cm-cli/pkg/cmd/create/authrealm/scenario"
+	createclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	hypershiftdeploymentscenario "github.com/stolostron/cm-cli/pkg/cmd/create/hypershiftdeployment/scenario"
+	deleteclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/delete/cluster/scenario"
+	detachclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/detach/cluster/scenario"
+	addonsscenario "github.com/stolostron/cm-cli/pkg/cmd/enable/addons/scenario"
+	getconfigclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/get/config/cluster/scenario"
+	getconfighypershiftdeploymentscenario "github.com/stolostron/cm-cli/pkg/cmd/get/config/hypershiftdeployment/scenario"
+	acmscenario "github.com/stolostron/cm-cli/pkg/cmd/install/acm/scenario"
+	mcescenario "github.com/stolostron/cm-cli/pkg/cmd/install/mce/scenario"
+	scaleclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/scale/cluster/scenario"
+	credentialsscenario "github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+)
+
+// readers are the readers of the embedded scenarios, a scenario can be used by several commands
+// (ie: attach-cluster by attach clusterclaim and attach hostedcluster)
+var readers = []*helpers.ScenarioReader{
+	attachclusterscenario.GetScenarioResourcesReader(),
+	clusterpoolhostscenario.GetScenarioResourcesReader(),
+	authrealmscenario.GetScenarioResourcesReader(),
+	createclusterscenario.GetScenarioResourcesReader(),
+	credentialsscenario.GetScenarioResourcesReader(),
+	hypershiftdeploymentscenario.GetScenarioResourcesReader(),
+	deleteclusterscenario.GetScenarioResourcesReader(),
+	detachclusterscenario.GetScenarioResourcesReader(),
+	addonsscenario.GetScenarioResourcesReader(),
+	getconfigclusterscenario.GetScenarioResourcesReader(),
+	getconfighypershiftdeploymentscenario.GetScenarioResourcesReader(),
+	acmscenario.GetScenarioResourcesReader(),
+	mcescenario.GetScenarioResourcesReader(),
+	scaleclusterscenario.GetScenarioResourcesReader(),
+}
+
+// Names returns the sorted names of the scenarios
+func Names() []string {
+	names := make([]string, 0, len(readers))
+	for _, r := range readers {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the reader of the scenario
+func Get(name string) (*helpers.ScenarioReader, error) {
+	for _, r := range readers {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown scenario %s, the scenarios are: %s", name, strings.Join(Names(), ", "))
+}
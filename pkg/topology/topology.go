@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+package topology
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// SNO is a single node cluster, one master and no worker
+	SNO = "sno"
+	// Compact is a 3 nodes cluster, the masters are schedulable and there is no worker
+	Compact = "compact"
+	// Standard is a cluster with 3 masters and separate workers
+	Standard = "standard"
+)
+
+// snoClouds are the clouds on which a single node cluster can be installed
+var snoClouds = []string{"aws", "azure", "gcp", "baremetal"}
+
+// Apply reads the topology at <root>.topology, checks it is supported by the cloud and
+// sets the <root>.master.replicas and <root>.worker.replicas of the topology.
+// Without topology the replicas are left unchanged and Standard is returned.
+func Apply(values map[string]interface{}, root, cloud string) (string, error) {
+	topology, _, err := unstructured.NestedString(values, root, "topology")
+	if err != nil {
+		return "", err
+	}
+	masters, workers := -1, -1
+	switch topology {
+	case "":
+		return Standard, nil
+	case SNO:
+		if !isSupported(snoClouds, cloud) {
+			return "", fmt.Errorf("the %s topology is supported on %s and got %s", SNO, strings.Join(snoClouds, ", "), cloud)
+		}
+		masters, workers = 1, 0
+	case Compact:
+		masters, workers = 3, 0
+	case Standard:
+		if workers, err = Replicas(values, root+".worker.replicas", 3); err != nil {
+			return "", err
+		}
+		if workers == 0 {
+			return "", fmt.Errorf("the %s topology requires workers, use the %s topology for a cluster without worker", Standard, Compact)
+		}
+		if masters, err = Replicas(values, root+".master.replicas", 3); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("topology must be %s, %s or %s and got %s", SNO, Compact, Standard, topology)
+	}
+	if err := setReplicas(values, root+".master", masters); err != nil {
+		return "", err
+	}
+	if err := setReplicas(values, root+".worker", workers); err != nil {
+		return "", err
+	}
+	return topology, nil
+}
+
+// Replicas returns the integer at the doted path or def if it is not set
+func Replicas(values map[string]interface{}, dotedPath string, def int) (int, error) {
+	v, ok, err := unstructured.NestedFieldNoCopy(values, strings.Split(dotedPath, ".")...)
+	if err != nil || !ok || v == nil {
+		return def, nil
+	}
+	switch i := v.(type) {
+	case int:
+		return i, nil
+	case int64:
+		return int(i), nil
+	case float64:
+		return int(i), nil
+	}
+	return 0, fmt.Errorf("%s must be an integer and got %v", dotedPath, v)
+}
+
+// setReplicas sets the replicas of the pool, an empty pool (ie: `worker:` in the values) is replaced
+func setReplicas(values map[string]interface{}, dotedPath string, replicas int) error {
+	fields := strings.Split(dotedPath, ".")
+	pool, _, err := unstructured.NestedFieldNoCopy(values, fields...)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		if err := unstructured.SetNestedField(values, map[string]interface{}{}, fields...); err != nil {
+			return err
+		}
+	}
+	return unstructured.SetNestedField(values, int64(replicas), append(fields, "replicas")...)
+}
+
+func isSupported(clouds []string, cloud string) bool {
+	for _, c := range clouds {
+		if c == cloud {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the Open Cluster Management project
+package topology
+
+import (
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name         string
+		pool         map[string]interface{}
+		cloud        string
+		wantTopology string
+		wantMasters  int
+		wantWorkers  int
+		wantErr      bool
+	}{
+		{
+			name:         "no topology keeps the replicas",
+			pool:         map[string]interface{}{"worker": map[string]interface{}{"replicas": float64(5)}},
+			cloud:        "aws",
+			wantTopology: Standard,
+			wantMasters:  -1,
+			wantWorkers:  5,
+		},
+		{
+			name:         "sno",
+			pool:         map[string]interface{}{"topology": SNO, "master": nil, "worker": map[string]interface{}{"replicas": float64(3)}},
+			cloud:        "gcp",
+			wantTopology: SNO,
+			wantMasters:  1,
+			wantWorkers:  0,
+		},
+		{
+			name:    "sno not supported on openstack",
+			pool:    map[string]interface{}{"topology": SNO},
+			cloud:   "openstack",
+			wantErr: true,
+		},
+		{
+			name:         "compact",
+			pool:         map[string]interface{}{"topology": Compact},
+			cloud:        "vsphere",
+			wantTopology: Compact,
+			wantMasters:  3,
+			wantWorkers:  0,
+		},
+		{
+			name:         "standard defaults",
+			pool:         map[string]interface{}{"topology": Standard},
+			cloud:        "azure",
+			wantTopology: Standard,
+			wantMasters:  3,
+			wantWorkers:  3,
+		},
+		{
+			name:    "standard without worker",
+			pool:    map[string]interface{}{"topology": Standard, "worker": map[string]interface{}{"replicas": float64(0)}},
+			cloud:   "aws",
+			wantErr: true,
+		},
+		{
+			name:    "unknown topology",
+			pool:    map[string]interface{}{"topology": "edge"},
+			cloud:   "aws",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{"managedCluster": tt.pool}
+			topology, err := Apply(values, "managedCluster", tt.cloud)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if topology != tt.wantTopology {
+				t.Errorf("got topology %s, want %s", topology, tt.wantTopology)
+			}
+			if masters, _ := Replicas(values, "managedCluster.master.replicas", -1); masters != tt.wantMasters {
+				t.Errorf("got %d masters, want %d", masters, tt.wantMasters)
+			}
+			if workers, _ := Replicas(values, "managedCluster.worker.replicas", -1); workers != tt.wantWorkers {
+				t.Errorf("got %d workers, want %d", workers, tt.wantWorkers)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright Contributors to the Open Cluster Management project
+package valuesschemas
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/stolostron/applier/pkg/asset"
+	clusterpoolhostscenario "github.com/stolostron/cm-cli/pkg/clusterpoolhost/scenario"
+	attachclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/attach/cluster/scenario"
+	authrealmscenario "github.com/stolostron/cm-cli/pkg/cmd/create/authrealm/scenario"
+	createclusterscenario "github.com/stolostron/cm-cli/pkg/cmd/create/cluster/scenario"
+	hypershiftdeploymentscenario "github.com/stolostron/cm-cli/pkg/cmd/create/hypershiftdeployment/scenario"
+	addonsscenario "github.com/stolostron/cm-cli/pkg/cmd/enable/addons/scenario"
+	credentialsscenario "github.com/stolostron/cm-cli/pkg/credentials/scenario"
+	"github.com/stolostron/cm-cli/pkg/helpers"
+)
+
+// ValuesSchema is the schema of the values of a command, stored next to its values template
+type ValuesSchema struct {
+	Reader asset.ScenarioReader
+	Path   string
+}
+
+// schemas are the schemas of the values of the commands
+var schemas = map[string]ValuesSchema{
+	"attach-cluster":              {attachclusterscenario.GetScenarioResourcesReader(), filepath.Join("attach", helpers.ValuesSchemaFileName)},
+	"create-authrealm":            {authrealmscenario.GetScenarioResourcesReader(), filepath.Join("create", helpers.ValuesSchemaFileName)},
+	"create-cluster":              {createclusterscenario.GetScenarioResourcesReader(), filepath.Join("create", helpers.ValuesSchemaFileName)},
+	"create-clusterpool":          {clusterpoolhostscenario.GetScenarioResourcesReader(), filepath.Join("create", "clusterpool", "common", helpers.ValuesSchemaFileName)},
+	"create-credentials":          {credentialsscenario.GetScenarioResourcesReader(), filepath.Join("create", helpers.ValuesSchemaFileName)},
+	"create-hypershiftdeployment": {hypershiftdeploymentscenario.GetScenarioResourcesReader(), filepath.Join("create", helpers.ValuesSchemaFileName)},
+	"enable-addons":               {addonsscenario.GetScenarioResourcesReader(), filepath.Join("addons", helpers.ValuesSchemaFileName)},
+}
+
+// aliases are the other names of the commands
+var aliases = map[string]string{
+	"create-cp": "create-clusterpool",
+	"create-hd": "create-hypershiftdeployment",
+}
+
+// Names returns the sorted names of the commands having a values schema
+func Names() []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Name returns the name of the command, resolving the aliases
+func Name(name string) string {
+	if n, ok := aliases[name]; ok {
+		return n
+	}
+	return name
+}
+
+// Get returns the values schema of the command name or an alias
+func Get(name string) (ValuesSchema, bool) {
+	s, ok := schemas[Name(name)]
+	return s, ok
+}
+
+// TemplatePath returns the path of the values template next to the schema
+func (s ValuesSchema) TemplatePath() string {
+	return filepath.Join(filepath.Dir(s.Path), "values-template.yaml")
+}
+
+// Load reads the schema
+func (s ValuesSchema) Load() (*helpers.Schema, error) {
+	return helpers.LoadSchema(s.Reader, s.Path)
+}
+
+// Validate validates the values against the schema
+func (s ValuesSchema) Validate(values map[string]interface{}) error {
+	return helpers.ValidateValues(s.Reader, s.Path, values)
+}
@@ -0,0 +1,23 @@
+// Copyright Contributors to the Open Cluster Management project
+package valuesschemas
+
+import (
+	"testing"
+
+	"github.com/stolostron/cm-cli/pkg/helpers"
+)
+
+func TestValuesTemplates(t *testing.T) {
+	for _, name := range Names() {
+		s, _ := Get(name)
+		t.Run(name, func(t *testing.T) {
+			values, err := helpers.ConvertReaderFileToValuesMap(s.TemplatePath(), s.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Validate(values); err != nil {
+				t.Errorf("the values template %s is not valid: %v", s.TemplatePath(), err)
+			}
+		})
+	}
+}